@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(agendaCmd)
+	setAgendaCmdFlags()
+}
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda [<todolist>...] [--days=<n=7>] [--from=<datetime>]",
+	Short: "print the upcoming tasks grouped by day",
+	Long: `agenda [<todolist>...] [--days=<n=7>] [--from=<datetime>]
+  if no arg is provided, the tasks of all lists are gathered.
+  lists the due dates, ends, deadlines and reminders falling within the
+  next days by time of day, expanding recurring tasks into their occurrences.
+  overdue tasks are listed first and running events are marked.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return err
+		}
+		if days < 1 || days > 366 {
+			return fmt.Errorf("%w: %w: days must be between '1' and '366' not '%d'", terrors.ErrFlag, terrors.ErrValue, days)
+		}
+		task.AdjustTime()
+		from := time.Now()
+		if val, _ := cmd.Flags().GetString("from"); val != "" {
+			dt, err := task.ParseDatetime(val)
+			if err != nil {
+				return fmt.Errorf("%w: --from: %w", terrors.ErrFlag, err)
+			}
+			from = *dt
+		}
+
+		if len(args) < 1 {
+			args, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		for _, arg := range args {
+			if err := loadFile(arg); err != nil {
+				return err
+			}
+			defer releaseFile(arg)
+		}
+		return task.PrintAgenda(args, from, days)
+	},
+}
+
+func setAgendaCmdFlags() {
+	agendaCmd.Flags().Int("days", 7, "number of days to cover")
+	agendaCmd.Flags().String("from", "", "the first day to cover; defaults to today")
+}
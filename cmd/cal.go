@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(calCmd)
+	setCalCmdFlags()
+}
+
+var calCmd = &cobra.Command{
+	Use:   "cal [<todolist>...] [--week|--month] [--start=<weekday>] [--at=<datetime>] [--width=<n>]",
+	Short: "print the tasks within a calendar",
+	Long: `cal [<todolist>...] [--week|--month] [--start=<weekday>] [--at=<datetime>] [--width=<n>]
+  if no arg is provided, the tasks of all lists are laid out.
+  lays the tasks with a '$due' into a grid of days; the week is the default.
+  events are spanned from due to end, deadlines are marked with '!'
+  and recurring tasks are expanded into all their occurrences.
+  --start designates the first day of the week; defaults to 'time.week-start'.
+  --at picks the week or month to show; defaults to today.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		week, err := cmd.Flags().GetBool("week")
+		if err != nil {
+			return err
+		}
+		month, err := cmd.Flags().GetBool("month")
+		if err != nil {
+			return err
+		}
+		if week && month {
+			return fmt.Errorf("%w: --week and --month are mutually exclusive", terrors.ErrFlag)
+		}
+		startStr := viper.GetString("time.week-start")
+		if cmd.Flags().Changed("start") {
+			startStr, _ = cmd.Flags().GetString("start")
+		}
+		start, err := task.ParseWeekday(startStr)
+		if err != nil {
+			return fmt.Errorf("%w: --start: %w", terrors.ErrFlag, err)
+		}
+		width, err := cmd.Flags().GetInt("width")
+		if err != nil {
+			return err
+		}
+		if width < 62 || width > 300 {
+			return fmt.Errorf("%w: %w: width must be between '62' and '300' not '%d'", terrors.ErrFlag, terrors.ErrValue, width)
+		}
+
+		task.AdjustTime()
+		day := time.Now()
+		if at, _ := cmd.Flags().GetString("at"); at != "" {
+			dt, err := task.ParseDatetime(at)
+			if err != nil {
+				return fmt.Errorf("%w: --at: %w", terrors.ErrFlag, err)
+			}
+			day = *dt
+		}
+
+		if len(args) < 1 {
+			args, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		for _, arg := range args {
+			if err := loadFile(arg); err != nil {
+				return err
+			}
+			defer releaseFile(arg)
+		}
+		return task.PrintCalendar(args, day, month, start, width)
+	},
+}
+
+func setCalCmdFlags() {
+	calCmd.Flags().Bool("week", false, "show a week; the default")
+	calCmd.Flags().Bool("month", false, "show a month")
+	calCmd.Flags().String("start", "", "the first day of the week")
+	calCmd.Flags().String("at", "", "a datetime within the week or month to show")
+	calCmd.Flags().Int("width", 120, "width of the calendar")
+}
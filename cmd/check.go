@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"dotxt/pkg/task"
+	"fmt"
 
 	"github.com/spf13/cobra"
 )
@@ -16,7 +17,8 @@ var checkCmd = &cobra.Command{
 	Long: `check [<todolist>]
   if no arg is provided, the check is performed for all files.
   check for a variety of things to fix:
-  	- recurrence of a task based on '$every'`,
+  	- recurrence of a task based on '$every'
+  	- units of progress tasks missing from 'progress.units' and 'progress.aliases'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var paths []string
 		if len(args) < 1 {
@@ -30,7 +32,14 @@ var checkCmd = &cobra.Command{
 		}
 		for _, path := range paths {
 			if err := loadFuncStoreFile(path, func() error {
-				return task.CheckAndRecurTasks(path)
+				if err := task.CheckAndRecurTasks(path); err != nil {
+					return err
+				}
+				warnings, err := task.CheckUnits(path)
+				for _, warning := range warnings {
+					fmt.Fprintln(cmd.ErrOrStderr(), warning)
+				}
+				return err
 			}); err != nil {
 				return err
 			}
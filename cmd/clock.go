@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(startCmd, stopCmd, reportCmd)
+	reportCmd.AddCommand(reportTimeCmd)
+	setStartCmdFlags()
+	setStopCmdFlags()
+	setReportTimeCmdFlags()
+}
+
+var startCmd = &cobra.Command{
+	Use:   "start <id> [--list=<todolist=todo>]",
+	Short: "clock in a task",
+	Long: `start <id> [--list=<todolist=todo>]
+  starts tracking the time spent on the task in the time log of the list;
+  a timer running for another task of the list is stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return terrors.ErrorArgNotProvided("id")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return terrors.ErrorArgParse("id", err)
+		}
+		path, err := prepTodoListArg(cmd)
+		if err != nil {
+			return err
+		}
+		if err := loadFile(path); err != nil {
+			return err
+		}
+		defer releaseFile(path)
+		task.AdjustTime()
+		return task.StartClock(id, path)
+	},
+}
+
+func setStartCmdFlags() {
+	startCmd.Flags().String("list", "", "designate the target todolist")
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop [<id>] [--list=<todolist=todo>]",
+	Short: "clock out a task",
+	Long: `stop [<id>] [--list=<todolist=todo>]
+  stops the timer of the task; every running timer of the list if no id is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id *int
+		if len(args) > 0 {
+			num, err := strconv.Atoi(args[0])
+			if err != nil {
+				return terrors.ErrorArgParse("id", err)
+			}
+			id = &num
+		}
+		path, err := prepTodoListArg(cmd)
+		if err != nil {
+			return err
+		}
+		if err := loadFile(path); err != nil {
+			return err
+		}
+		defer releaseFile(path)
+		task.AdjustTime()
+		return task.StopClock(id, path)
+	},
+}
+
+func setStopCmdFlags() {
+	stopCmd.Flags().String("list", "", "designate the target todolist")
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "print reports of the lists",
+}
+
+var reportTimeCmd = &cobra.Command{
+	Use:   "time [<todolist>...] [--by=<hint|list|day>] [--since=<datetime>]",
+	Short: "summarise the tracked time",
+	Long: `time [<todolist>...] [--by=<hint|list|day>] [--since=<datetime>]
+  if no arg is provided, the time logs of all lists are summarised.
+  adds up the time tracked with start and stop by hint, list or day;
+  running timers are counted up to now.
+  --since takes a datetime or a duration to look back from now; e.g. --since=1w`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, err := cmd.Flags().GetString("by")
+		if err != nil {
+			return err
+		}
+		task.AdjustTime()
+		var since *time.Time
+		if val, _ := cmd.Flags().GetString("since"); val != "" {
+			since, err = task.ParseSince(val)
+			if err != nil {
+				return fmt.Errorf("%w: --since: %w", terrors.ErrFlag, err)
+			}
+		}
+		if len(args) < 1 {
+			args, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		return task.PrintTimeReport(args, by, since)
+	},
+}
+
+func setReportTimeCmdFlags() {
+	reportTimeCmd.Flags().String("by", "hint", "group by 'hint', 'list' or 'day'")
+	reportTimeCmd.Flags().String("since", "", "only count the time tracked since then")
+	reportTimeCmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions(
+		[]string{"hint", "list", "day"}, cobra.ShellCompDirectiveNoFileComp))
+}
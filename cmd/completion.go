@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"dotxt/config"
+	"dotxt/pkg/task"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type completionFunc = func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// attaches the dynamic completion functions to the commands.
+// it has to happen after all the flags are defined.
+func registerCompletions() {
+	for _, cmd := range []*cobra.Command{delCmd, doneCmd, deprioritizeCmd} {
+		cmd.ValidArgsFunction = withConfig(completeTaskIDs)
+	}
+	for _, cmd := range []*cobra.Command{prioritizeCmd, toggleCollapseCmd, incCmd, setCoundCmd, lsNCmd, print1, startCmd, stopCmd, snoozeCmd, progressCmd} {
+		cmd.ValidArgsFunction = withConfig(completeTaskID)
+	}
+	for _, cmd := range []*cobra.Command{appendCmd, prependCmd, replaceCmd} {
+		cmd.ValidArgsFunction = withConfig(completeTaskIDThenText)
+	}
+	for _, cmd := range []*cobra.Command{sortCmd, printCmd, checkCmd, tuiCmd, migrateCmd, remindCmd, calCmd, agendaCmd, reportTimeCmd, planCmd, todayCmd, matrixCmd, conflictsCmd, freeCmd} {
+		cmd.ValidArgsFunction = withConfig(completeLists)
+	}
+	addCmd.ValidArgsFunction = withConfig(completeTaskText)
+	moveCmd.ValidArgsFunction = withConfig(completeMove)
+
+	var walk func(*cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.Flags().Lookup("list") != nil {
+			cmd.RegisterFlagCompletionFunc("list", withConfig(completeLists))
+		}
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+}
+
+// the completion command does not parse flags before the initializers run,
+// so the designated config is loaded once the flags are available
+func withConfig(f completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if arg, err := cmd.Flags().GetString("config"); err == nil && arg != "" {
+			viper.Reset()
+			if err := config.InitViper(arg); err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+		}
+		return f(cmd, args, toComplete)
+	}
+}
+
+func completeLists(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := task.ListNames()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeIDsOf(path string) ([]string, cobra.ShellCompDirective) {
+	if err := loadFile(path); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	out, err := task.TaskCompletions(path)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// every argument is an id
+func completeTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	path, err := prepTodoListArg(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return completeIDsOf(path)
+}
+
+// only the first argument is an id
+func completeTaskID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeTaskIDs(cmd, args, toComplete)
+}
+
+func completeTaskIDThenText(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeTaskIDs(cmd, args, toComplete)
+	}
+	return completeTaskText(cmd, args, toComplete)
+}
+
+// hints of all lists after +, @, #, etc. and special token keys after $
+func completeTaskText(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if strings.HasPrefix(toComplete, "$") {
+		return task.TokenCompletions(toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+	if toComplete == "" || !strings.ContainsRune("+@#!?*&", rune(toComplete[0])) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	paths, err := task.LsFiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	for _, path := range paths {
+		loadFile(path)
+	}
+	return task.HintCompletions(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// move <from> <id> <to>
+func completeMove(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0, 2:
+		return completeLists(cmd, args, toComplete)
+	case 1:
+		return completeIDsOf(args[0])
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
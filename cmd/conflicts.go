@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(conflictsCmd)
+	setConflictsCmdFlags()
+}
+
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts [<todolist>...] [--days=<n=30>]",
+	Short: "print the events that overlap",
+	Long: `conflicts [<todolist>...] [--days=<n=30>]
+  if no arg is provided, the events of all lists are checked.
+  events are tasks with both a '$due' and an '$end'; recurring ones are
+  expanded into their occurrences within the next days.
+  the occurrences of a single recurring event never conflict with each other.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return err
+		}
+		if days < 1 || days > 366 {
+			return fmt.Errorf("%w: %w: days must be between '1' and '366' not '%d'", terrors.ErrFlag, terrors.ErrValue, days)
+		}
+		task.AdjustTime()
+		if len(args) < 1 {
+			args, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		for _, arg := range args {
+			if err := loadFile(arg); err != nil {
+				return err
+			}
+			defer releaseFile(arg)
+		}
+		return task.PrintConflicts(args, time.Now(), days)
+	},
+}
+
+// warns about the events of all lists the task overlaps within 30 days of its due
+func warnConflicts(cmd *cobra.Command, id int, path string) error {
+	paths, err := task.LsFiles()
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		if err := loadFile(p); err != nil {
+			return err
+		}
+	}
+	conflicts, err := task.TaskConflicts(id, path, 30)
+	if err != nil {
+		return err
+	}
+	for _, line := range task.FormatConflictWarnings(conflicts) {
+		fmt.Fprintln(cmd.ErrOrStderr(), line)
+	}
+	return nil
+}
+
+func setConflictsCmdFlags() {
+	conflictsCmd.Flags().Int("days", 30, "number of days to check")
+}
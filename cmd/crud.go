@@ -17,7 +17,7 @@ func init() {
 		addCmd, delCmd, appendCmd,
 		prependCmd, replaceCmd,
 		deduplicateCmd, deprioritizeCmd,
-		prioritizeCmd, doneCmd,
+		prioritizeCmd, snoozeCmd, doneCmd,
 		revertCmd, moveCmd, migrateCmd,
 		lsNCmd, sortCmd)
 	setAddCmdFlags()
@@ -28,6 +28,7 @@ func init() {
 	setDeduplicateCmdFlags()
 	setDeprioritizeCmdFlags()
 	setPrioritizeCmdFlags()
+	setSnoozeCmdFlags()
 	setRevertCmdFlags()
 	setDoneCmdFlags()
 	setMigrateCmdFlags()
@@ -35,24 +36,56 @@ func init() {
 	setSortCmdFlags()
 }
 
+// within a shell session the lists are kept in memory across commands
+func loadFile(path string) error {
+	if session != nil {
+		return session.load(path, false)
+	}
+	return task.LoadFile(path)
+}
+
+func loadOrCreateFile(path string) error {
+	if session != nil {
+		return session.load(path, true)
+	}
+	return task.LoadOrCreateFile(path)
+}
+
+func storeFile(path string) error {
+	if session != nil {
+		return session.store(path)
+	}
+	return task.StoreFile(path)
+}
+
+// marks a list which might have been altered in memory without being stored
+// (e.g. sorted for printing or left half-way by an error) to be reloaded
+func releaseFile(path string) {
+	if session != nil {
+		session.release(path)
+	}
+}
+
 func loadFuncStoreFile(path string, f func() error) error {
-	if err := task.LoadFile(path); err != nil {
+	if err := loadFile(path); err != nil {
 		return err
 	}
 	if err := f(); err != nil {
+		releaseFile(path)
 		return err
 	}
-	return task.StoreFile(path)
+	return storeFile(path)
 }
 
 func loadorcreateFuncStoreFile(path string, f func() error) error {
-	if err := task.LoadOrCreateFile(path); err != nil {
+	if err := loadOrCreateFile(path); err != nil {
 		return err
 	}
 	if err := f(); err != nil {
+		releaseFile(path)
 		return err
 	}
-	return task.StoreFile(path)
+	return storeFile(path)
 }
 
 func prepTodoListArg(cmd *cobra.Command) (string, error) {
@@ -67,24 +100,38 @@ func prepTodoListArg(cmd *cobra.Command) (string, error) {
 }
 
 var addCmd = &cobra.Command{
-	Use:   "add <task> [--list=<todolist=todo>]",
+	Use:   "add <task> [--list=<todolist=todo>] [--check-overlap]",
 	Short: "add task",
-	Long: `add <task> [--list=<todolist=todo>]
-  adds task to todolist`,
+	Long: `add <task> [--list=<todolist=todo>] [--check-overlap]
+  adds task to todolist
+  --check-overlap warns about the events of all lists the new one overlaps.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		arg := strings.Join(args, " ")
 		path, err := prepTodoListArg(cmd)
 		if err != nil {
 			return err
 		}
-		return loadorcreateFuncStoreFile(path, func() error {
-			return task.AddTaskFromStr(arg, path)
+		var id int
+		err = loadorcreateFuncStoreFile(path, func() error {
+			if err := task.AddTaskFromStr(arg, path); err != nil {
+				return err
+			}
+			id, err = task.LastID(path)
+			return err
 		})
+		if err != nil {
+			return err
+		}
+		if check, _ := cmd.Flags().GetBool("check-overlap"); check {
+			return warnConflicts(cmd, id, path)
+		}
+		return nil
 	},
 }
 
 func setAddCmdFlags() {
 	addCmd.Flags().String("list", "", "designate the target todolist")
+	addCmd.Flags().Bool("check-overlap", false, "warn about overlapping events")
 }
 
 var delCmd = &cobra.Command{
@@ -193,10 +240,11 @@ func setPrependCmdFlags() {
 }
 
 var replaceCmd = &cobra.Command{
-	Use:   "replace <id> <task> [--list=<todolist=todo>]",
+	Use:   "replace <id> <task> [--list=<todolist=todo>] [--check-overlap]",
 	Short: "replace line with a new task",
-	Long: `replace|update <id> <task> [--list=<todolist=todo>]
-  replace line with a new task`,
+	Long: `replace|update <id> <task> [--list=<todolist=todo>] [--check-overlap]
+  replace line with a new task
+  --check-overlap warns about the events of all lists the new one overlaps.`,
 	Aliases: []string{"update"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
@@ -214,14 +262,22 @@ var replaceCmd = &cobra.Command{
 			return err
 		}
 		text := strings.Join(args[1:], " ")
-		return loadFuncStoreFile(path, func() error {
+		err = loadFuncStoreFile(path, func() error {
 			return task.ReplaceTask(id, text, path)
 		})
+		if err != nil {
+			return err
+		}
+		if check, _ := cmd.Flags().GetBool("check-overlap"); check {
+			return warnConflicts(cmd, id, path)
+		}
+		return nil
 	},
 }
 
 func setReplaceCmdFlags() {
 	replaceCmd.Flags().String("list", "", "designate the target todolist")
+	replaceCmd.Flags().Bool("check-overlap", false, "warn about overlapping events")
 }
 
 var deduplicateCmd = &cobra.Command{
@@ -318,11 +374,48 @@ func setPrioritizeCmdFlags() {
 	prioritizeCmd.Flags().String("list", "", "designate the target todolist")
 }
 
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <id> <duration|datetime> [--list=<todolist=todo>]",
+	Short: "hide task for a while",
+	Long: `snooze <id> <duration|datetime> [--list=<todolist=todo>]
+  sets the threshold ($t) of the task so that it is hidden from print until then;
+  a duration is taken from now, e.g. 'snooze 3 2d'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return terrors.ErrorArgNotProvided("id")
+		}
+		if len(args) < 2 {
+			return terrors.ErrorArgNotProvided("duration")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return terrors.ErrorArgParse("id", err)
+		}
+		task.AdjustTime()
+		until, err := task.ParseDatetime(strings.Join(args[1:], " "))
+		if err != nil {
+			return terrors.ErrorArgParse("duration", err)
+		}
+		path, err := prepTodoListArg(cmd)
+		if err != nil {
+			return err
+		}
+		return loadFuncStoreFile(path, func() error {
+			return task.SnoozeTask(id, *until, path)
+		})
+	},
+}
+
+func setSnoozeCmdFlags() {
+	snoozeCmd.Flags().String("list", "", "designate the target todolist")
+}
+
 var doneCmd = &cobra.Command{
-	Use:   "done <id> [--list==<todolist=todo>]",
+	Use:   "done <id> [--list==<todolist=todo>] [--finish]",
 	Short: "finish and move task",
-	Long: `do|done <id> [--list==<todolist=todo>]
-  finish task`,
+	Long: `do|done <id> [--list==<todolist=todo>] [--finish]
+  finish task; recurring tasks are logged and moved to their next occurrence
+  unless --finish is given`,
 	Aliases: []string{"do"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
@@ -336,11 +429,18 @@ var doneCmd = &cobra.Command{
 			}
 			ids = append(ids, num)
 		}
+		finish, err := cmd.Flags().GetBool("finish")
+		if err != nil {
+			return err
+		}
 		path, err := prepTodoListArg(cmd)
 		if err != nil {
 			return err
 		}
 		return loadFuncStoreFile(path, func() error {
+			if finish {
+				return task.FinishTask(ids, path)
+			}
 			return task.DoneTask(ids, path)
 		})
 	},
@@ -348,6 +448,7 @@ var doneCmd = &cobra.Command{
 
 func setDoneCmdFlags() {
 	doneCmd.Flags().String("list", "", "designate the target todolist")
+	doneCmd.Flags().Bool("finish", false, "end the series of recurring tasks")
 }
 
 var revertCmd = &cobra.Command{
@@ -408,19 +509,19 @@ var moveCmd = &cobra.Command{
 			return err
 		}
 
-		if err = task.LoadFile(from); err != nil {
+		if err = loadFile(from); err != nil {
 			return err
 		}
-		if err = task.LoadOrCreateFile(to); err != nil {
+		if err = loadOrCreateFile(to); err != nil {
 			return err
 		}
 		if err = task.MoveTask(from, id, to); err != nil {
 			return err
 		}
-		if err = task.StoreFile(to); err != nil {
+		if err = storeFile(to); err != nil {
 			return err
 		}
-		if err = task.StoreFile(from); err != nil {
+		if err = storeFile(from); err != nil {
 			return err
 		}
 		return nil
@@ -472,7 +573,7 @@ var lsNCmd = &cobra.Command{
 			return err
 		}
 
-		if err := task.LoadFile(path); err != nil {
+		if err := loadFile(path); err != nil {
 			return err
 		}
 		return task.OutputTask(id, path)
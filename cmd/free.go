@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(freeCmd)
+	setFreeCmdFlags()
+}
+
+var freeCmd = &cobra.Command{
+	Use:   "free [<todolist>...] [--days=<n=5>] [--min=<duration=30M>] [--propose]",
+	Short: "print the free time between events",
+	Long: `free [<todolist>...] [--days=<n=5>] [--min=<duration=30M>] [--propose]
+  if no arg is provided, the events of all lists are taken into account.
+  prints the spans within 'time.work-hours' of the working days ('time.work-days'
+  without the holidays) that no event ('$due' to '$end') takes up.
+  --min leaves out the spans shorter than it.
+  --propose places the tasks with a '$est' but no '$due' into the earliest spans
+  they fit in and prints the '$due' each would get; nothing is written.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return err
+		}
+		if days < 1 || days > 366 {
+			return fmt.Errorf("%w: %w: days must be between '1' and '366' not '%d'", terrors.ErrFlag, terrors.ErrValue, days)
+		}
+		minStr, err := cmd.Flags().GetString("min")
+		if err != nil {
+			return err
+		}
+		minLen, err := task.ParseDuration(minStr)
+		if err != nil {
+			return fmt.Errorf("%w: --min: %w", terrors.ErrFlag, err)
+		}
+		propose, err := cmd.Flags().GetBool("propose")
+		if err != nil {
+			return err
+		}
+		task.AdjustTime()
+		if len(args) < 1 {
+			args, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		for _, arg := range args {
+			if err := loadFile(arg); err != nil {
+				return err
+			}
+			defer releaseFile(arg)
+		}
+		return task.PrintFreeSlots(args, time.Now(), days, *minLen, propose)
+	},
+}
+
+func setFreeCmdFlags() {
+	freeCmd.Flags().Int("days", 5, "number of days to cover")
+	freeCmd.Flags().String("min", "30M", "the shortest span to print")
+	freeCmd.Flags().Bool("propose", false, "propose due dates for estimated tasks without one")
+}
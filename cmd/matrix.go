@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(matrixCmd)
+	setMatrixCmdFlags()
+}
+
+var matrixCmd = &cobra.Command{
+	Use:   "matrix [<todolist>...] [--width=<n>]",
+	Short: "print the tasks within an eisenhower matrix",
+	Long: `matrix [<todolist>...] [--width=<n>]
+  if no arg is provided, the tasks of all lists are gathered.
+  places the tasks into four quadrants by whether they're urgent and important.
+  urgent ones are marked '$urgent', '$mit' or have a date within their urgency window;
+  important ones have a priority at or above 'matrix.importance'.
+  tasks hidden by '$t' are left out.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		width, err := cmd.Flags().GetInt("width")
+		if err != nil {
+			return err
+		}
+		if width < 40 || width > 300 {
+			return fmt.Errorf("%w: %w: width must be between '40' and '300' not '%d'", terrors.ErrFlag, terrors.ErrValue, width)
+		}
+		task.AdjustTime()
+		if len(args) < 1 {
+			args, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		for _, arg := range args {
+			if err := loadFile(arg); err != nil {
+				return err
+			}
+			defer releaseFile(arg)
+		}
+		return task.PrintMatrix(args, width)
+	},
+}
+
+func setMatrixCmdFlags() {
+	matrixCmd.Flags().Int("width", 120, "width of the matrix")
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(todayCmd)
+	setPlanCmdFlags()
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan [<todolist>...] [--accept] [--order=<n>,...]",
+	Short: "propose the tasks of today and rank them as mits",
+	Long: `plan [<todolist>...] [--accept] [--order=<n>,...]
+  if no arg is provided, the tasks of all lists are gathered.
+  proposes the current mits, overdue tasks, running events, tasks due today
+  and the ones marked urgent as the tasks of today.
+  --accept ranks the proposal as '$mit=' in the given order and removes
+  other mits of the lists. --order takes the numbers of the proposal in
+  the order they're to be ranked in; the ones left out are dropped.
+  mits of a plan from a previous day are removed first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		accept, err := cmd.Flags().GetBool("accept")
+		if err != nil {
+			return err
+		}
+		order, err := cmd.Flags().GetIntSlice("order")
+		if err != nil {
+			return err
+		}
+		if len(order) > 0 && !accept {
+			return fmt.Errorf("%w: --order requires --accept", terrors.ErrFlag)
+		}
+		task.AdjustTime()
+		args, err = loadFrontierLists(args)
+		if err != nil {
+			return err
+		}
+		items, err := task.ProposePlan(args)
+		if err != nil {
+			return err
+		}
+		if !accept {
+			fmt.Println(strings.Join(task.RenderPlan(items), "\n"))
+			return nil
+		}
+		if len(order) > 0 {
+			var chosen []*task.PlanItem
+			for _, n := range order {
+				if n < 1 || n > len(items) {
+					return fmt.Errorf("%w: %w: --order must hold numbers between '1' and '%d' not '%d'", terrors.ErrFlag, terrors.ErrValue, len(items), n)
+				}
+				if slices.Contains(chosen, items[n-1]) {
+					return fmt.Errorf("%w: %w: --order holds '%d' more than once", terrors.ErrFlag, terrors.ErrValue, n)
+				}
+				chosen = append(chosen, items[n-1])
+			}
+			items = chosen
+		}
+		if err := task.AcceptPlan(args, items); err != nil {
+			return err
+		}
+		for _, arg := range args {
+			if err := storeFile(arg); err != nil {
+				return err
+			}
+		}
+		return printToday(args)
+	},
+}
+
+var todayCmd = &cobra.Command{
+	Use:   "today [<todolist>...]",
+	Short: "print the mits of today",
+	Long: `today [<todolist>...]
+  if no arg is provided, the tasks of all lists are gathered.
+  prints the tasks ranked by 'plan' in order.
+  mits of a plan from a previous day are removed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		task.AdjustTime()
+		args, err := loadFrontierLists(args)
+		if err != nil {
+			return err
+		}
+		return printToday(args)
+	},
+}
+
+// loads the lists (all of them if none are given); the mits of a stale plan
+// are removed from every list since the plan spanned them all.
+func loadFrontierLists(args []string) ([]string, error) {
+	all, err := task.LsFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(args) < 1 {
+		args = all
+	}
+	for _, arg := range append(slices.Clone(args), all...) {
+		if err := loadFile(arg); err != nil {
+			return nil, err
+		}
+	}
+	cleared, err := task.ClearStaleMITs(all)
+	if err != nil {
+		return nil, err
+	}
+	if cleared {
+		for _, path := range all {
+			if err := storeFile(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return args, nil
+}
+
+func printToday(paths []string) error {
+	lines, err := task.RenderToday(paths)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
+
+func setPlanCmdFlags() {
+	planCmd.Flags().Bool("accept", false, "rank the proposal as the mits of today")
+	planCmd.Flags().IntSlice("order", nil, "the numbers of the proposal to rank in order")
+}
@@ -20,7 +20,10 @@ var printCmd = &cobra.Command{
 	Use:   "print <todolist=todo>...",
 	Short: "print tasks from lists",
 	Long: `print <todolist=todo>...
-  print tasks from lists`,
+  print tasks from lists; tasks whose threshold ($t) is yet to come
+  are hidden unless --all-tasks is given.
+  tasks due beyond 'print.horizon' are summarised as later unless
+  --later or --all-tasks is given.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		maxlen, err := cmd.Flags().GetInt("maxlen")
 		if err != nil {
@@ -44,6 +47,15 @@ var printCmd = &cobra.Command{
 		if len(args) < 1 {
 			all = true
 		}
+		allTasks, err := cmd.Flags().GetBool("all-tasks")
+		if err != nil {
+			return err
+		}
+		later, err := cmd.Flags().GetBool("later")
+		if err != nil {
+			return err
+		}
+		task.ShowDeferred, task.ShowLater = allTasks, allTasks || later
 		if all {
 			var err error
 			args, err = task.LsFiles()
@@ -52,9 +64,10 @@ var printCmd = &cobra.Command{
 			}
 		}
 		for _, arg := range args {
-			if err := task.LoadFile(arg); err != nil {
+			if err := loadFile(arg); err != nil {
 				return err
 			}
+			defer releaseFile(arg)
 		}
 		return task.PrintLists(args, maxlen, minlen)
 	},
@@ -62,6 +75,8 @@ var printCmd = &cobra.Command{
 
 func setPrintCmdFlags() {
 	printCmd.Flags().Bool("all", false, "print all lists")
+	printCmd.Flags().Bool("all-tasks", false, "print tasks hidden by their threshold or the horizon too")
+	printCmd.Flags().Bool("later", false, "print tasks beyond the horizon too")
 	printCmd.Flags().Int("maxlen", 80, "maximum length")
 	printCmd.Flags().Int("minlen", 80, "maximum length")
 }
@@ -119,7 +134,7 @@ var print1 = &cobra.Command{
 			return err
 		}
 
-		if err := task.LoadFile(path); err != nil {
+		if err := loadFile(path); err != nil {
 			return err
 		}
 		return task.PrintTask(id, path, maxlen)
@@ -9,9 +9,10 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(incCmd, setCoundCmd)
+	rootCmd.AddCommand(incCmd, setCoundCmd, progressCmd)
 	setIncCmdFlags()
 	setCountCmdFlags()
+	setProgressCmdFlags()
 }
 
 var incCmd = &cobra.Command{
@@ -39,6 +40,7 @@ var incCmd = &cobra.Command{
 			return err
 		}
 
+		task.AdjustTime()
 		return loadFuncStoreFile(path, func() error {
 			return task.IncrementProgressCount(id, path, val)
 		})
@@ -74,6 +76,7 @@ var setCoundCmd = &cobra.Command{
 			return err
 		}
 
+		task.AdjustTime()
 		return loadFuncStoreFile(path, func() error {
 			return task.SetProgressCount(id, path, val)
 		})
@@ -83,3 +86,35 @@ var setCoundCmd = &cobra.Command{
 func setCountCmdFlags() {
 	setCoundCmd.Flags().String("list", "", "designate the target todolist")
 }
+
+var progressCmd = &cobra.Command{
+	Use:   "progress id [--list=<todolist=todo>]",
+	Short: "show the history and pace of a progress task",
+	Long: `progress id [--list=<todolist=todo>]
+  prints the counts recorded by inc and setc along with the average rate
+  per day and week and the date it projects to; if the task has a $dead,
+  whether the current pace meets it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return terrors.ErrorArgNotProvided("id")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return terrors.ErrorArgParse("id", err)
+		}
+		path, err := prepTodoListArg(cmd)
+		if err != nil {
+			return err
+		}
+		if err := loadFile(path); err != nil {
+			return err
+		}
+		defer releaseFile(path)
+		task.AdjustTime()
+		return task.PrintProgress(id, path)
+	},
+}
+
+func setProgressCmdFlags() {
+	progressCmd.Flags().String("list", "", "designate the target todolist")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"dotxt/pkg/logging"
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(remindCmd)
+	setRemindCmdFlags()
+}
+
+var remindCmd = &cobra.Command{
+	Use:   "remind [<todolist>...] [--due | --watch [--interval=<duration>]]",
+	Short: "fire the reminders whose time has passed",
+	Long: `remind [<todolist>...] [--due | --watch [--interval=<duration>]]
+  if no arg is provided, the reminders of all lists are checked.
+  each passed '$r' fires once; the fired ones are recorded under '_etc'.
+  --due fires the passed reminders once and exits; this is the default.
+  --watch keeps checking every 'remind.interval' until interrupted.
+  if 'remind.command' is set it is run through 'sh -c' for each reminder
+  with the task text as $1 and the following environment:
+    DOTXT_LIST, DOTXT_ID, DOTXT_TASK, DOTXT_REMINDER
+  otherwise the reminders are printed to stdout.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		watch, err := cmd.Flags().GetBool("watch")
+		if err != nil {
+			return err
+		}
+		due, err := cmd.Flags().GetBool("due")
+		if err != nil {
+			return err
+		}
+		if watch && due {
+			return fmt.Errorf("%w: --due and --watch are mutually exclusive", terrors.ErrFlag)
+		}
+		if !watch {
+			return remind(args)
+		}
+
+		interval := viper.GetDuration("remind.interval")
+		if cmd.Flags().Changed("interval") {
+			interval, err = cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return err
+			}
+		}
+		if interval < time.Second {
+			return fmt.Errorf("%w: %w: interval must be at least a second not '%s'", terrors.ErrFlag, terrors.ErrValue, interval)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := remind(args); err != nil {
+				logging.Logger.Error(err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+func setRemindCmdFlags() {
+	remindCmd.Flags().Bool("due", false, "fire the passed reminders once")
+	remindCmd.Flags().Bool("watch", false, "keep firing reminders as they pass")
+	remindCmd.Flags().Duration("interval", 0, "how often to check when watching")
+}
+
+// loads the lists afresh and fires their pending reminders
+func remind(paths []string) error {
+	if len(paths) < 1 {
+		var err error
+		paths, err = task.LsFiles()
+		if err != nil {
+			return err
+		}
+	}
+	task.AdjustTime()
+	for _, path := range paths {
+		if err := loadFile(path); err != nil {
+			return err
+		}
+		defer releaseFile(path)
+	}
+	return task.FireReminders(paths, fireReminder)
+}
+
+func fireReminder(r *task.Reminder) error {
+	text := r.Task.Norm()
+	at := r.At.In(task.LocalZone()).Format("2006-01-02T15:04")
+	command := viper.GetString("remind.command")
+	if command == "" {
+		fmt.Printf("%s:%d %s %s\n", r.List, r.ID, at, text)
+		return nil
+	}
+	c := exec.Command("sh", "-c", command, "sh", text)
+	c.Env = append(os.Environ(),
+		"DOTXT_LIST="+r.List,
+		"DOTXT_ID="+strconv.Itoa(r.ID),
+		"DOTXT_TASK="+text,
+		"DOTXT_REMINDER="+at,
+	)
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("remind.command: %w", err)
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ import (
 
 const version = "0.0.0"
 
+var initialized bool
+
 var rootCmd = &cobra.Command{
 	Use:           "dotxt",
 	Short:         fmt.Sprintf("dotxt %s: a text based todo list inspired by todotxt", version),
@@ -50,6 +52,10 @@ Global Flags:
 {{ .InheritedFlags.FlagUsages | trimTrailingWhitespaces }}{{end}}{{end}}{{end}}
 `)
 	cobra.OnInitialize(func() {
+		if initialized { // e.g. commands run within a shell session
+			return
+		}
+		initialized = true
 		arg, err := rootCmd.PersistentFlags().GetString("config")
 		if err != nil {
 			logging.Logger.Fatal(err)
@@ -75,6 +81,7 @@ Global Flags:
 }
 
 func Execute() error {
+	registerCompletions()
 	return rootCmd.Execute()
 }
 
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"dotxt/pkg/logging"
+	"dotxt/pkg/server"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	setServeCmdFlags()
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [--addr=<host:port|unix:/path>] [--token=<token>]",
+	Short: "serve the lists over a local http/json api",
+	Long: `serve [--addr=<host:port|unix:/path>] [--token=<token>]
+  serve the lists over a local http/json api; defaults come from the 'serve' config section.
+  if a token is set, requests must carry 'Authorization: Bearer <token>'.
+  the list is designated by the 'list' query parameter, defaulting to todo.
+  endpoints:
+    GET    /lists                   names of all lists
+    GET    /tasks                   tasks of a list
+    POST   /tasks                   add {"text": ...}
+    POST   /tasks/revert            revert {"ids": [...]} from the done file
+    GET    /tasks/{id}              a single task
+    PUT    /tasks/{id}              replace {"text": ...}
+    DELETE /tasks/{id}              delete
+    POST   /tasks/{id}/append       append {"text": ...}
+    POST   /tasks/{id}/prepend      prepend {"text": ...}
+    POST   /tasks/{id}/done         mark done
+    PUT    /tasks/{id}/priority     prioritize {"priority": ...}
+    DELETE /tasks/{id}/priority     deprioritize
+    POST   /tasks/{id}/progress     {"increment": n} or {"count": n}
+    POST   /tasks/{id}/collapse     toggle collapse
+    POST   /tasks/{id}/focus        toggle focus`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, token := viper.GetString("serve.addr"), viper.GetString("serve.token")
+		if cmd.Flags().Changed("addr") {
+			addr, _ = cmd.Flags().GetString("addr")
+		}
+		if cmd.Flags().Changed("token") {
+			token, _ = cmd.Flags().GetString("token")
+		}
+		logging.Logger.Infof("serving on %s", addr)
+		return server.Serve(addr, token)
+	},
+}
+
+func setServeCmdFlags() {
+	serveCmd.Flags().String("addr", "", "address to listen upon; host:port or unix:/path")
+	serveCmd.Flags().String("token", "", "bearer token required from clients")
+}
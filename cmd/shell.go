@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bytes"
+	"dotxt/config"
+	"dotxt/pkg/logging"
+	"dotxt/pkg/shell"
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+	setShellCmdFlags()
+}
+
+var session *shellSession
+
+type shellSession struct {
+	autosave bool
+	// modification times of the loaded lists upon load or store
+	loaded map[string]time.Time
+	dirty  map[string]bool
+	// root persistent flags as given to the shell command itself
+	rootFlags map[string]string
+}
+
+var shellCmd = &cobra.Command{
+	Use:   "shell [--autosave=true]",
+	Short: "interactive shell keeping lists in memory",
+	Long: `shell [--autosave=true]
+  reads and runs commands the same as the command line, with history and tab completion,
+  while keeping the lists in memory. lists changed on disk are reloaded before use.
+  builtins:
+    save [!]           store the modified lists; '!' overwrites changes made on disk
+    reload [<list>...] drop the in-memory state of lists; all if none is provided
+    status             show the modified lists
+    exit|quit [!]      leave the shell; '!' discards the unsaved modifications`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if session != nil {
+			return fmt.Errorf("%w: already in a shell", terrors.ErrArg)
+		}
+		autosave, err := cmd.Flags().GetBool("autosave")
+		if err != nil {
+			return err
+		}
+		session = &shellSession{
+			autosave: autosave,
+			loaded:   make(map[string]time.Time),
+			dirty:    make(map[string]bool),
+		}
+		defer func() { session = nil }()
+		session.rootFlags = make(map[string]string)
+		rootCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+			session.rootFlags[f.Name] = f.Value.String()
+		})
+
+		sh := &shell.Shell{
+			Prompt:      "dotxt> ",
+			In:          os.Stdin,
+			Out:         os.Stdout,
+			Exec:        session.exec,
+			Complete:    session.complete,
+			HistoryPath: filepath.Join(config.ConfigPath(), "shell_history"),
+		}
+		return sh.Run()
+	},
+}
+
+func setShellCmdFlags() {
+	shellCmd.Flags().Bool("autosave", true, "store modified lists after each command; otherwise only upon 'save'")
+}
+
+func (s *shellSession) exec(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	force := len(args) > 1 && args[1] == "!"
+	if name, ok := strings.CutSuffix(args[0], "!"); ok {
+		args[0], force = name, true
+	}
+	var err error
+	switch args[0] {
+	case "exit", "quit":
+		if len(s.dirty) > 0 && !force {
+			fmt.Fprintf(os.Stderr, "unsaved lists: %s; 'save' them or '%s!' to discard\n", strings.Join(s.dirtyNames(), ", "), args[0])
+			return false
+		}
+		return true
+	case "save":
+		err = s.save(force)
+	case "reload":
+		err = s.reload(args[1:])
+	case "status":
+		for _, name := range s.dirtyNames() {
+			fmt.Println("modified:", name)
+		}
+	case "shell":
+		err = fmt.Errorf("%w: already in a shell", terrors.ErrArg)
+	default:
+		err = s.run(args)
+	}
+	if err != nil { // the console logger is usually too quiet for an interactive session
+		logging.Logger.Errorf("error running command: %v", err)
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+	return false
+}
+
+// runs args as a command line
+func (s *shellSession) run(args []string) error {
+	task.AdjustTime()
+	defer s.resetFlags()
+	rootCmd.SetArgs(args)
+	return rootCmd.Execute()
+}
+
+// flags keep their values across executions, so they are put back to their defaults
+func (s *shellSession) resetFlags() {
+	reset := func(f *pflag.Flag) {
+		value, ok := s.rootFlags[f.Name]
+		if !ok || rootCmd.PersistentFlags().Lookup(f.Name) != f {
+			value = f.DefValue
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+		} else {
+			f.Value.Set(value)
+		}
+		f.Changed = false
+	}
+	var walk func(*cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		cmd.Flags().VisitAll(reset)
+		cmd.PersistentFlags().VisitAll(reset)
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+}
+
+// the candidates of cobra's own completion for the command line
+func (s *shellSession) complete(args []string, partial string) []string {
+	var out bytes.Buffer
+	prevOut, prevErr := rootCmd.OutOrStdout(), rootCmd.ErrOrStderr()
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	defer func() {
+		rootCmd.SetOut(prevOut)
+		rootCmd.SetErr(prevErr)
+	}()
+	if err := s.run(slices.Concat([]string{cobra.ShellCompRequestCmd}, args, []string{partial})); err != nil {
+		return nil
+	}
+	var candidates []string
+	for line := range strings.SplitSeq(out.String(), "\n") {
+		if strings.HasPrefix(line, ":") {
+			break
+		}
+		if line != "" {
+			candidates = append(candidates, line)
+		}
+	}
+	if len(args) == 0 {
+		candidates = append(candidates, "save", "reload", "status", "exit", "quit")
+	}
+	return candidates
+}
+
+func modTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// loads the list unless it's in memory and unchanged on disk;
+// modified lists are kept regardless.
+func (s *shellSession) load(path string, create bool) error {
+	abs, err := task.AbsListPath(path)
+	if err != nil {
+		return err
+	}
+	if loadedAt, ok := s.loaded[abs]; ok && task.Lists.Exists(abs) {
+		if s.dirty[abs] {
+			return nil
+		}
+		if mtime, err := modTime(abs); err == nil && mtime.Equal(loadedAt) {
+			return nil
+		}
+		logging.Logger.Infof("reloading '%s' which changed on disk", task.ListName(abs))
+	}
+	if create {
+		err = task.LoadOrCreateFile(abs)
+	} else {
+		err = task.LoadFile(abs)
+	}
+	if err != nil {
+		return err
+	}
+	mtime, err := modTime(abs)
+	if err != nil {
+		return err
+	}
+	s.loaded[abs] = mtime
+	return nil
+}
+
+func (s *shellSession) store(path string) error {
+	abs, err := task.AbsListPath(path)
+	if err != nil {
+		return err
+	}
+	s.dirty[abs] = true
+	if !s.autosave {
+		return nil
+	}
+	return s.flush(abs, false)
+}
+
+func (s *shellSession) flush(path string, force bool) error {
+	if mtime, err := modTime(path); err == nil && !force {
+		if loadedAt, ok := s.loaded[path]; ok && !mtime.Equal(loadedAt) {
+			return fmt.Errorf("%w: '%s' changed on disk since loaded; 'reload' discards the modifications, 'save!' overwrites", terrors.ErrValue, task.ListName(path))
+		}
+	}
+	if err := task.StoreFile(path); err != nil {
+		return err
+	}
+	mtime, err := modTime(path)
+	if err != nil {
+		return err
+	}
+	s.loaded[path] = mtime
+	delete(s.dirty, path)
+	return nil
+}
+
+func (s *shellSession) release(path string) {
+	abs, err := task.AbsListPath(path)
+	if err != nil || s.dirty[abs] {
+		return
+	}
+	delete(s.loaded, abs)
+}
+
+func (s *shellSession) save(force bool) error {
+	for _, path := range slices.Sorted(maps.Keys(s.dirty)) {
+		if err := s.flush(path, force); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *shellSession) reload(lists []string) error {
+	if len(lists) == 0 {
+		lists = slices.Collect(maps.Keys(s.loaded))
+	}
+	for _, list := range lists {
+		abs, err := task.AbsListPath(list)
+		if err != nil {
+			return err
+		}
+		delete(s.dirty, abs)
+		delete(s.loaded, abs)
+	}
+	return nil
+}
+
+func (s *shellSession) dirtyNames() []string {
+	var out []string
+	for _, path := range slices.Sorted(maps.Keys(s.dirty)) {
+		out = append(out, task.ListName(path))
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/tui"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	setTuiCmdFlags()
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [<todolist>...] [--list=<todolist=todo>]",
+	Short: "interactive full-screen interface",
+	Long: `tui [<todolist>...] [--list=<todolist=todo>]
+  interactive full-screen interface upon the given lists; all lists if none is provided.
+  keys:
+    j/k, up/down       move the cursor
+    tab/l, s-tab/h     switch lists; 1-9 jumps to a list
+    enter/space        toggle collapse
+    f                  toggle focus
+    x                  mark done
+    p                  change priority
+    +/-                increment/decrement progress
+    e                  edit the line inline
+    a                  add a task
+    /                  filter; esc clears the filter
+    r                  reload
+    q                  quit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := args
+		if len(paths) < 1 {
+			var err error
+			paths, err = task.LsFiles()
+			if err != nil {
+				return err
+			}
+		}
+		if len(paths) < 1 {
+			if err := task.LoadOrCreateFile(task.DefaultTodo); err != nil {
+				return err
+			}
+			paths = []string{task.DefaultTodo}
+		}
+		for ndx := range paths {
+			var err error
+			paths[ndx], err = task.AbsListPath(paths[ndx])
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(paths[ndx]); err != nil {
+				return err
+			}
+		}
+		start := 0
+		if cmd.Flags().Changed("list") {
+			list, err := prepTodoListArg(cmd)
+			if err != nil {
+				return err
+			}
+			list, err = task.AbsListPath(list)
+			if err != nil {
+				return err
+			}
+			for ndx, path := range paths {
+				if path == list {
+					start = ndx
+				}
+			}
+		}
+		return tui.Run(paths, start)
+	},
+}
+
+func setTuiCmdFlags() {
+	tuiCmd.Flags().String("list", "", "designate the initially shown todolist")
+}
@@ -67,7 +67,8 @@ func InitViper(arg string) error {
 	if err != nil {
 		return fmt.Errorf("%w: default configurations: %w", terrors.ErrParse, err)
 	}
-	err = viper.ReadInConfig()
+	// merged so that keys missing from older config files fall back to the defaults
+	err = viper.MergeInConfig()
 	if err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 			return err
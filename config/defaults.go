@@ -60,6 +60,8 @@ color-date-end           = '{{ index .Colors "orange-light" }}'
 color-date-dead          = '{{ index .Colors "orange-light" }}'
 color-date-r             = '{{ index .Colors "jade-light" }}'
 color-urgent  			 = '{{ index .Colors "red-light" }}'
+color-imminent           = '{{ index .Colors "red-dark" }}'
+color-overdue            = '{{ index .Colors "red" }}'
 color-mit				 = '{{ index .Colors "blue-sky" }}'
 color-every              = '{{ index .Colors "yellow-light" }}'
 color-dead-relations     = '{{ index .Colors "grey" }}'
@@ -67,6 +69,9 @@ color-collapsed          = '{{ index .Colors "orange-light" }}'
 color-focus  			 = '{{ index .Colors "red-light" }}'
 color-hidden			 = '{{ index .Colors "grey-light" }}'
 color-anti-priority      = '{{ index .Colors "grey-light" }}'
+color-estimate           = '{{ index .Colors "cyan-pale" }}'
+color-overloaded         = '{{ index .Colors "red-dark" }}'
+horizon                  = ""
 
 [print.hints]
 color-at          = '{{ index .Colors "blue" }}'
@@ -106,6 +111,36 @@ saturation  = 0.7
 lightness   = 0.6
 start-hue   = 0
 end-hue     = 360
+
+[time]
+week-start = "sat"
+zone       = ""
+work-days  = "mon,tue,wed,thu,fri"
+work-hours = "09:00-17:00"
+capacity   = "8h"
+
+[urgency]
+window   = "1m"
+imminent = "2d"
+
+[urgency.lists]
+
+[urgency.hints]
+
+[matrix]
+importance = "B"
+
+[progress.units]
+
+[progress.aliases]
+
+[serve]
+addr  = "127.0.0.1:8468"
+token = ""
+
+[remind]
+command  = ""
+interval = "1m"
 `
 
 func init() {
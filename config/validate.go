@@ -4,6 +4,10 @@ import (
 	"dotxt/pkg/terrors"
 	"dotxt/pkg/utils"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 	"unicode"
 
 	"github.com/spf13/viper"
@@ -32,12 +36,20 @@ func validateConfig() []error {
 				"color-date-dead", "color-date-r", "color-every",
 				"color-dead-relations", "color-collapsed",
 				"color-hidden", "color-anti-priority", "color-urgent",
+				"color-imminent", "color-overdue",
+				"color-estimate", "color-overloaded",
 			} {
 				if err := validateColor("print." + key); err != nil {
 					errs = append(errs, err)
 				}
 			}
 		}
+		// print.horizon
+		if err := validateTypeString("print.horizon"); err != nil {
+			errs = append(errs, err)
+		} else if val := viper.GetString("print.horizon"); !horizonPattern.MatchString(val) {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'print.horizon' must be 'week', 'week:<duration>' or a duration not '%s'", terrors.ErrConf, terrors.ErrValue, val))
+		}
 		// print.hints.*
 		{
 			for _, key := range []string{
@@ -112,9 +124,128 @@ func validateConfig() []error {
 			}
 		}
 	}
+
+	// time.*
+	{
+		if err := validateTypeString("time.week-start"); err != nil {
+			errs = append(errs, err)
+		} else if val := strings.ToLower(viper.GetString("time.week-start")); !isWeekday(val) {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'time.week-start' must be a weekday not '%s'", terrors.ErrConf, terrors.ErrValue, val))
+		}
+		if err := validateTypeString("time.work-days"); err != nil {
+			errs = append(errs, err)
+		} else if val := strings.ToLower(viper.GetString("time.work-days")); slices.ContainsFunc(
+			strings.Split(val, ","), func(day string) bool { return !isWeekday(strings.TrimSpace(day)) }) {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'time.work-days' must be comma separated weekdays not '%s'", terrors.ErrConf, terrors.ErrValue, val))
+		}
+		if err := validateTypeString("time.work-hours"); err != nil {
+			errs = append(errs, err)
+		} else if val := viper.GetString("time.work-hours"); !validWorkHours(val) {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'time.work-hours' must be 'HH:MM-HH:MM' with the start before the end not '%s'", terrors.ErrConf, terrors.ErrValue, val))
+		}
+		if err := validateTypeString("time.capacity"); err != nil {
+			errs = append(errs, err)
+		} else if val := viper.GetString("time.capacity"); val != "" && !durationPattern.MatchString(val) {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'time.capacity' must be empty or a duration not '%s'", terrors.ErrConf, terrors.ErrValue, val))
+		}
+		if err := validateTypeString("time.zone"); err != nil {
+			errs = append(errs, err)
+		} else if val := viper.GetString("time.zone"); val != "" {
+			if _, err := utils.ParseZone(val); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %w: config key 'time.zone': %w", terrors.ErrConf, terrors.ErrValue, err))
+			}
+		}
+	}
+
+	// urgency.*
+	{
+		for _, key := range []string{"window", "imminent"} {
+			if err := validateTypeString("urgency." + key); err != nil {
+				errs = append(errs, err)
+			} else if val := viper.GetString("urgency." + key); !durationPattern.MatchString(val) {
+				errs = append(errs, fmt.Errorf("%w: %w: config key 'urgency.%s' must be a duration not '%s'", terrors.ErrConf, terrors.ErrValue, key, val))
+			}
+		}
+		for _, table := range []string{"lists", "hints"} {
+			for name, val := range viper.GetStringMap("urgency." + table) {
+				if str, ok := val.(string); !ok || !durationPattern.MatchString(str) {
+					errs = append(errs, fmt.Errorf("%w: %w: config key 'urgency.%s.%s' must be a duration not '%v'", terrors.ErrConf, terrors.ErrValue, table, name, val))
+				}
+			}
+		}
+	}
+
+	// matrix.*
+	{
+		if err := validateTypeString("matrix.importance"); err != nil {
+			errs = append(errs, err)
+		} else if viper.GetString("matrix.importance") == "" {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'matrix.importance' must not be empty", terrors.ErrConf, terrors.ErrValue))
+		}
+	}
+
+	// progress.*
+	{
+		for name, val := range viper.GetStringMap("progress.units") {
+			if str, ok := val.(string); !ok || strings.Contains(str, "/") {
+				errs = append(errs, fmt.Errorf("%w: %w: config key 'progress.units.%s' must be a category without '/' not '%v'", terrors.ErrConf, terrors.ErrValue, name, val))
+			}
+		}
+		for name, val := range viper.GetStringMap("progress.aliases") {
+			if str, ok := val.(string); !ok || str == "" || strings.Contains(str, "/") {
+				errs = append(errs, fmt.Errorf("%w: %w: config key 'progress.aliases.%s' must be a unit without '/' not '%v'", terrors.ErrConf, terrors.ErrValue, name, val))
+			}
+		}
+	}
+
+	// serve.*
+	{
+		for _, key := range []string{"addr", "token"} {
+			if err := validateTypeString("serve." + key); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// remind.*
+	{
+		if err := validateTypeString("remind.command"); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateTypeString("remind.interval"); err != nil {
+			errs = append(errs, err)
+		} else if val, err := time.ParseDuration(viper.GetString("remind.interval")); err != nil || val < time.Second {
+			errs = append(errs, fmt.Errorf("%w: %w: config key 'remind.interval' must be a duration of at least a second not '%s'", terrors.ErrConf, terrors.ErrValue, viper.GetString("remind.interval")))
+		}
+	}
 	return errs
 }
 
+// durations like '2d12h'
+const durationExpr = `[+-]?([0-9.]+[ymwdhMs])+`
+
+var durationPattern = regexp.MustCompile(`^` + durationExpr + `$`)
+
+// empty, 'week', 'week:<duration>' or '<duration>'
+var horizonPattern = regexp.MustCompile(`^(|week|(week:)?` + durationExpr + `)$`)
+
+// full names or prefixes of at least 3 letters
+func isWeekday(val string) bool {
+	return len(val) >= 3 && slices.ContainsFunc(
+		[]string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"},
+		func(day string) bool { return strings.HasPrefix(day, val) })
+}
+
+var workHoursPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):([0-5][0-9])-([01][0-9]|2[0-3]):([0-5][0-9])$`)
+
+func validWorkHours(val string) bool {
+	parts := workHoursPattern.FindStringSubmatch(val)
+	if parts == nil {
+		return false
+	}
+	return parts[1]+parts[2] < parts[3]+parts[4]
+}
+
 func validateHue(key string) error {
 	if err := validateTypeInt(key); err != nil {
 		return err
@@ -0,0 +1,422 @@
+package server
+
+import (
+	"crypto/subtle"
+	"dotxt/pkg/task"
+	"dotxt/pkg/terrors"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An http handler exposing the task api as json
+type Server struct {
+	// when not empty, requests must carry "Authorization: Bearer <Token>"
+	Token string
+
+	mu  sync.Mutex // lists live in a shared global; one request at a time
+	mux *http.ServeMux
+}
+
+func New(token string) *Server {
+	s := &Server{Token: token, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /lists", s.getLists)
+	s.mux.HandleFunc("GET /tasks", s.getTasks)
+	s.mux.HandleFunc("POST /tasks", s.addTask)
+	s.mux.HandleFunc("POST /tasks/revert", s.revertTasks)
+	s.mux.HandleFunc("GET /tasks/{id}", s.getTask)
+	s.mux.HandleFunc("PUT /tasks/{id}", s.replaceTask)
+	s.mux.HandleFunc("DELETE /tasks/{id}", s.deleteTask)
+	s.mux.HandleFunc("POST /tasks/{id}/append", s.appendToTask)
+	s.mux.HandleFunc("POST /tasks/{id}/prepend", s.prependToTask)
+	s.mux.HandleFunc("POST /tasks/{id}/done", s.doneTask)
+	s.mux.HandleFunc("PUT /tasks/{id}/priority", s.prioritizeTask)
+	s.mux.HandleFunc("DELETE /tasks/{id}/priority", s.deprioritizeTask)
+	s.mux.HandleFunc("POST /tasks/{id}/progress", s.progressTask)
+	s.mux.HandleFunc("POST /tasks/{id}/collapse", s.collapseTask)
+	s.mux.HandleFunc("POST /tasks/{id}/focus", s.focusTask)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// listens upon "host:port" or "unix:/path/to/socket"
+func Listen(addr string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		if path == "" {
+			return nil, fmt.Errorf("%w: empty unix socket path", terrors.ErrValue)
+		}
+		if info, err := os.Stat(path); err == nil && info.Mode()&fs.ModeSocket != 0 {
+			os.Remove(path) // stale socket of a previous run
+		}
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+func Serve(addr, token string) error {
+	listener, err := Listen(addr)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	srv := &http.Server{Handler: New(token), ReadHeaderTimeout: 10 * time.Second}
+	return srv.Serve(listener)
+}
+
+type taskProgress struct {
+	Unit      string `json:"unit"`
+	Category  string `json:"category,omitempty"`
+	Count     int    `json:"count"`
+	DoneCount int    `json:"doneCount"`
+}
+
+type taskJSON struct {
+	ID        int           `json:"id"`
+	Text      string        `json:"text"`
+	Raw       string        `json:"raw"`
+	Priority  string        `json:"priority,omitempty"`
+	Hints     []string      `json:"hints,omitempty"`
+	EID       string        `json:"eid,omitempty"`
+	Parent    *int          `json:"parent,omitempty"`
+	Children  []int         `json:"children,omitempty"`
+	Depth     int           `json:"depth"`
+	Collapsed bool          `json:"collapsed"`
+	Urgent    bool          `json:"urgent"`
+	MIT       *int          `json:"mit,omitempty"`
+	Focus     bool          `json:"focus"`
+	Created   *time.Time    `json:"created,omitempty"`
+	Due       *time.Time    `json:"due,omitempty"`
+	End       *time.Time    `json:"end,omitempty"`
+	Deadline  *time.Time    `json:"deadline,omitempty"`
+	Reminders []*time.Time  `json:"reminders,omitempty"`
+	Every     string        `json:"every,omitempty"`
+	Progress  *taskProgress `json:"progress,omitempty"`
+}
+
+func toJSON(t *task.Task) taskJSON {
+	out := taskJSON{
+		ID: *t.ID, Text: t.Norm(), Raw: t.Raw(),
+		Depth: t.Depth(), Collapsed: t.IsCollapsed(),
+		Urgent: t.Urgent, MIT: t.MIT,
+		Focus: t.Fmt != nil && t.Fmt.Focus,
+	}
+	if t.Priority != nil {
+		out.Priority = *t.Priority
+	}
+	for _, hint := range t.Hints {
+		out.Hints = append(out.Hints, *hint)
+	}
+	if t.EID != nil {
+		out.EID = *t.EID
+	}
+	if t.Parent != nil {
+		out.Parent = t.Parent.ID
+	}
+	for _, child := range t.Children {
+		out.Children = append(out.Children, *child.ID)
+	}
+	if t.Time != nil {
+		out.Created, out.Due = t.Time.CreationDate, t.Time.DueDate
+		out.End, out.Deadline = t.Time.EndDate, t.Time.Deadline
+		out.Reminders = t.Time.Reminders
+		if t.Time.Every != nil {
+			out.Every = t.Time.Every.String()
+		}
+	}
+	if t.Prog != nil {
+		out.Progress = &taskProgress{
+			Unit: t.Prog.Unit, Category: t.Prog.Category,
+			Count: t.Prog.Count, DoneCount: t.Prog.DoneCount,
+		}
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, terrors.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, terrors.ErrArg), errors.Is(err, terrors.ErrParse),
+		errors.Is(err, terrors.ErrValue), errors.Is(err, terrors.ErrEmptyText),
+		errors.Is(err, terrors.ErrType):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// the "list" query parameter defaulting to the default list
+func listOf(r *http.Request) string {
+	if list := r.URL.Query().Get("list"); strings.TrimSpace(list) != "" {
+		return list
+	}
+	return task.DefaultTodo
+}
+
+func idOf(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return -1, terrors.ErrorArgParse("id", err)
+	}
+	return id, nil
+}
+
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: request body: %w", terrors.ErrParse, err)
+	}
+	return nil
+}
+
+// loads the list and runs f upon it; when store is set the list is stored afterwards
+func (s *Server) withList(path string, create, store bool, f func(path string) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task.AdjustTime()
+	load := task.LoadFile
+	if create {
+		load = task.LoadOrCreateFile
+	}
+	if err := load(path); err != nil {
+		return err
+	}
+	path, err := task.AbsListPath(path)
+	if err != nil {
+		return err
+	}
+	if err := f(path); err != nil {
+		return err
+	}
+	if store {
+		return task.StoreFile(path)
+	}
+	return nil
+}
+
+// the task with the given id after the request's operation
+func (s *Server) respondTask(w http.ResponseWriter, status int, t *task.Task, err error) {
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, status, toJSON(t))
+}
+
+func (s *Server) getLists(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	names, err := task.ListNames()
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	if names == nil {
+		names = []string{}
+	}
+	writeJSON(w, http.StatusOK, names)
+}
+
+func (s *Server) getTasks(w http.ResponseWriter, r *http.Request) {
+	out := []taskJSON{}
+	err := s.withList(listOf(r), false, false, func(path string) error {
+		for _, t := range task.Lists[path].Tasks {
+			out = append(out, toJSON(t))
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTask(w, r, false, func(id int, path string) error { return nil })
+}
+
+type textBody struct {
+	Text string `json:"text"`
+}
+
+func (s *Server) addTask(w http.ResponseWriter, r *http.Request) {
+	var body textBody
+	if err := decode(r, &body); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	var t *task.Task
+	err := s.withList(listOf(r), true, true, func(path string) error {
+		var err error
+		if t, err = task.ParseTask(nil, body.Text); err != nil {
+			return err
+		}
+		return task.AddTask(t, path)
+	})
+	s.respondTask(w, http.StatusCreated, t, err)
+}
+
+// runs f upon the task of the path's id and responds with the task
+func (s *Server) modifyTask(w http.ResponseWriter, r *http.Request, store bool, f func(id int, path string) error) {
+	id, err := idOf(r)
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	var t *task.Task
+	err = s.withList(listOf(r), false, store, func(path string) error {
+		if err := f(id, path); err != nil {
+			return err
+		}
+		t, err = task.GetTask(id, path)
+		return err
+	})
+	s.respondTask(w, http.StatusOK, t, err)
+}
+
+func (s *Server) modifyTaskText(w http.ResponseWriter, r *http.Request, f func(id int, text, path string) error) {
+	var body textBody
+	if err := decode(r, &body); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	s.modifyTask(w, r, true, func(id int, path string) error {
+		return f(id, body.Text, path)
+	})
+}
+
+func (s *Server) replaceTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTaskText(w, r, task.ReplaceTask)
+}
+
+func (s *Server) appendToTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTaskText(w, r, task.AppendToTask)
+}
+
+func (s *Server) prependToTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTaskText(w, r, task.PrependToTask)
+}
+
+// deletion and completion remove the task; the response carries no body
+func (s *Server) removeTask(w http.ResponseWriter, r *http.Request, f func(ids []int, path string) error) {
+	id, err := idOf(r)
+	if err == nil {
+		err = s.withList(listOf(r), false, true, func(path string) error {
+			return f([]int{id}, path)
+		})
+	}
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	s.removeTask(w, r, task.DeleteTasks)
+}
+
+// recurring tasks move on to their next occurrence unless ?finish=true
+func (s *Server) doneTask(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("finish") == "true" {
+		s.removeTask(w, r, task.FinishTask)
+		return
+	}
+	s.removeTask(w, r, task.DoneTask)
+}
+
+func (s *Server) revertTasks(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		IDs []int `json:"ids"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	if len(body.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, terrors.ErrNoArgsProvided)
+		return
+	}
+	err := s.withList(listOf(r), false, true, func(path string) error {
+		return task.RevertTask(body.IDs, path)
+	})
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) prioritizeTask(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Priority string `json:"priority"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	s.modifyTask(w, r, true, func(id int, path string) error {
+		return task.PrioritizeTask(id, body.Priority, path)
+	})
+}
+
+func (s *Server) deprioritizeTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTask(w, r, true, task.DeprioritizeTask)
+}
+
+func (s *Server) progressTask(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Increment *int `json:"increment"`
+		Count     *int `json:"count"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	if (body.Increment == nil) == (body.Count == nil) {
+		err := fmt.Errorf("%w: exactly one of 'increment' or 'count' must be provided", terrors.ErrArg)
+		writeError(w, statusOf(err), err)
+		return
+	}
+	s.modifyTask(w, r, true, func(id int, path string) error {
+		if body.Count != nil {
+			return task.SetProgressCount(id, path, *body.Count)
+		}
+		return task.IncrementProgressCount(id, path, *body.Increment)
+	})
+}
+
+func (s *Server) collapseTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTask(w, r, true, task.ToggleCollapsed)
+}
+
+func (s *Server) focusTask(w http.ResponseWriter, r *http.Request) {
+	s.modifyTask(w, r, true, task.ToggleFocus)
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bytes"
+	"dotxt/config"
+	"dotxt/pkg/task"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "dotxt-server-testing")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	config.InitViper(dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func request(t *testing.T, h http.Handler, method, target string, body any) *httptest.ResponseRecorder {
+	var reader bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reader).Encode(body))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, &reader))
+	return rec
+}
+
+func decodeTask(t *testing.T, rec *httptest.ResponseRecorder) taskJSON {
+	var out taskJSON
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+	return out
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.NoError(t, err)
+	config.SelectConfigFile(tmpDir) // a fresh list and done file on every run
+	s := New("")
+	list := "/tasks?list=server"
+
+	rec := request(t, s, "POST", list, textBody{"first +prj $id=p"})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	first := decodeTask(t, rec)
+	assert.Equal(0, first.ID)
+	assert.Equal([]string{"+prj"}, first.Hints)
+	rec = request(t, s, "POST", list, textBody{"child $P=p $p=page/0/10"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	child := decodeTask(t, rec)
+	assert.Equal(1, child.ID)
+	assert.Equal(&first.ID, child.Parent)
+
+	t.Run("lists", func(t *testing.T) {
+		rec := request(t, s, "GET", "/lists", nil)
+		assert.Equal(http.StatusOK, rec.Code)
+		var names []string
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&names))
+		assert.Contains(names, "server")
+	})
+	t.Run("get", func(t *testing.T) {
+		rec := request(t, s, "GET", list, nil)
+		assert.Equal(http.StatusOK, rec.Code)
+		var tasks []taskJSON
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&tasks))
+		assert.Len(tasks, 2)
+		assert.Equal([]int{1}, tasks[0].Children)
+
+		rec = request(t, s, "GET", "/tasks/1?list=server", nil)
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.Equal(&taskProgress{Unit: "page", DoneCount: 10}, decodeTask(t, rec).Progress)
+
+		rec = request(t, s, "GET", "/tasks/9?list=server", nil)
+		assert.Equal(http.StatusNotFound, rec.Code)
+		rec = request(t, s, "GET", "/tasks/x?list=server", nil)
+		assert.Equal(http.StatusBadRequest, rec.Code)
+		rec = request(t, s, "GET", "/tasks?list=nonexistent", nil)
+		assert.Equal(http.StatusNotFound, rec.Code)
+	})
+	t.Run("update", func(t *testing.T) {
+		rec := request(t, s, "POST", "/tasks/0/append?list=server", textBody{"more"})
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.Equal("first +prj $id=p more", decodeTask(t, rec).Text)
+
+		rec = request(t, s, "PUT", "/tasks/0/priority?list=server", map[string]string{"priority": "A"})
+		assert.Equal(http.StatusOK, rec.Code)
+		assert.Equal("(A)", decodeTask(t, rec).Priority)
+		rec = request(t, s, "DELETE", "/tasks/0/priority?list=server", nil)
+		assert.Equal("", decodeTask(t, rec).Priority)
+
+		rec = request(t, s, "POST", "/tasks/1/progress?list=server", map[string]int{"increment": 3})
+		assert.Equal(3, decodeTask(t, rec).Progress.Count)
+		rec = request(t, s, "POST", "/tasks/1/progress?list=server", map[string]int{"count": 7})
+		assert.Equal(7, decodeTask(t, rec).Progress.Count)
+		rec = request(t, s, "POST", "/tasks/1/progress?list=server", map[string]int{})
+		assert.Equal(http.StatusBadRequest, rec.Code)
+
+		rec = request(t, s, "POST", "/tasks/0/collapse?list=server", nil)
+		assert.True(decodeTask(t, rec).Collapsed)
+		rec = request(t, s, "POST", "/tasks/0/focus?list=server", nil)
+		assert.True(decodeTask(t, rec).Focus)
+
+		rec = request(t, s, "PUT", "/tasks/1?list=server", textBody{"replaced $P=p"})
+		assert.Equal("replaced $P=p", decodeTask(t, rec).Text)
+
+		data, err := os.ReadFile(filepath.Join(config.ConfigPath(), "todos", "server"))
+		require.NoError(t, err)
+		assert.Contains(string(data), "replaced $P=p")
+	})
+	t.Run("done and revert", func(t *testing.T) {
+		rec := request(t, s, "POST", "/tasks/1/done?list=server", nil)
+		assert.Equal(http.StatusNoContent, rec.Code)
+		require.NoError(t, task.LoadFile("server"))
+		assert.Equal(1, task.Lists.Len(filepath.Join(config.ConfigPath(), "todos", "server")))
+
+		rec = request(t, s, "POST", "/tasks/revert?list=server", map[string][]int{"ids": {0}})
+		assert.Equal(http.StatusNoContent, rec.Code, rec.Body.String())
+		rec = request(t, s, "DELETE", "/tasks/0?list=server", nil)
+		assert.Equal(http.StatusNoContent, rec.Code)
+		rec = request(t, s, "GET", list, nil)
+		var tasks []taskJSON
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&tasks))
+		require.Len(t, tasks, 1)
+		assert.Contains(tasks[0].Text, "replaced")
+	})
+}
+
+func TestAuth(t *testing.T) {
+	assert := assert.New(t)
+	s := New("secret")
+	rec := request(t, s, "GET", "/lists", nil)
+	assert.Equal(http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest("GET", "/lists", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	assert.Equal(http.StatusOK, rec.Code)
+}
+
+func TestListen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sock")
+	listener, err := Listen("unix:" + path)
+	require.NoError(t, err)
+	listener.Close()
+	_, err = Listen("unix:")
+	assert.Error(t, err)
+}
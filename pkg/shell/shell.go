@@ -0,0 +1,192 @@
+package shell
+
+import (
+	"bufio"
+	"dotxt/pkg/term"
+	"dotxt/pkg/terrors"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const historySize = 500
+
+// A read-eval loop upon a terminal or any other input
+type Shell struct {
+	Prompt string
+	In     *os.File
+	Out    io.Writer
+	// runs a single line split into args; returning true ends the loop
+	Exec func(args []string) (exit bool)
+	// completion candidates for partial given the preceding args
+	Complete func(args []string, partial string) []string
+	// file to load and persist history from and to; empty disables persistence
+	HistoryPath string
+
+	history []string
+}
+
+// runs the loop until Exec asks for an exit or input ends
+func (s *Shell) Run() error {
+	fd := int(s.In.Fd())
+	if !term.IsTerminal(fd) {
+		return s.runPlain()
+	}
+	s.loadHistory()
+	editor := &term.Editor{In: term.NewReader(s.In), Out: s.Out, Complete: s.complete}
+	for {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		editor.History = s.history
+		line, err := editor.ReadLine(s.Prompt, "")
+		term.Restore(fd, state)
+		fmt.Fprintln(s.Out)
+		if errors.Is(err, term.ErrInterrupted) {
+			continue
+		} else if errors.Is(err, io.EOF) {
+			if s.Exec([]string{"exit"}) {
+				return nil
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		s.addHistory(line)
+		if s.exec(line) {
+			return nil
+		}
+	}
+}
+
+// reads lines without editing; e.g. when input is piped
+func (s *Shell) runPlain() error {
+	scanner := bufio.NewScanner(s.In)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if s.exec(line) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	s.Exec([]string{"exit"})
+	return nil
+}
+
+func (s *Shell) exec(line string) bool {
+	args, err := Split(line)
+	if err != nil {
+		fmt.Fprintln(s.Out, err)
+		return false
+	}
+	return s.Exec(args)
+}
+
+func (s *Shell) complete(text string) []string {
+	if s.Complete == nil {
+		return nil
+	}
+	args, err := Split(text)
+	if err != nil {
+		return nil
+	}
+	partial := ""
+	if len(args) > 0 && !strings.HasSuffix(text, " ") {
+		partial = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	return s.Complete(args, partial)
+}
+
+func (s *Shell) loadHistory() {
+	if s.HistoryPath == "" {
+		return
+	}
+	data, err := os.ReadFile(s.HistoryPath)
+	if err != nil {
+		return
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			s.history = append(s.history, line)
+		}
+	}
+}
+
+func (s *Shell) addHistory(line string) {
+	if n := len(s.history); n > 0 && s.history[n-1] == line {
+		return
+	}
+	s.history = append(s.history, line)
+	if len(s.history) > historySize {
+		s.history = s.history[len(s.history)-historySize:]
+	}
+	if s.HistoryPath == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.HistoryPath), 0755); err != nil {
+		return
+	}
+	os.WriteFile(s.HistoryPath, []byte(strings.Join(s.history, "\n")+"\n"), 0644)
+}
+
+// splits a line into args the way a posix shell would, minus expansions;
+// single quotes keep everything, double quotes and backslashes escape.
+func Split(line string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return args, fmt.Errorf("%w: unterminated quote or escape", terrors.ErrParse)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,75 @@
+package shell
+
+import (
+	"dotxt/pkg/terrors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	assert := assert.New(t)
+	helper := func(line string) []string {
+		out, err := Split(line)
+		assert.NoError(err)
+		return out
+	}
+	assert.Nil(helper("   "))
+	assert.Equal([]string{"add", "task", "+prj"}, helper("add  task\t+prj "))
+	assert.Equal([]string{"add", "a task $due=1w"}, helper(`add "a task $due=1w"`))
+	assert.Equal([]string{"it's", `"q"`}, helper(`it\'s '"q"'`))
+	assert.Equal([]string{"", "x"}, helper(`"" x`))
+	_, err := Split(`add "open`)
+	assert.ErrorIs(err, terrors.ErrParse)
+}
+
+func TestRunPlain(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input")
+	require.NoError(t, os.WriteFile(input, []byte("add 'a b'\n\nprint\nexit\nnever\n"), 0644))
+	in, err := os.Open(input)
+	require.NoError(t, err)
+	defer in.Close()
+
+	var got [][]string
+	var out strings.Builder
+	s := &Shell{In: in, Out: &out, Exec: func(args []string) bool {
+		got = append(got, args)
+		return args[0] == "exit"
+	}}
+	require.NoError(t, s.Run())
+	assert.Equal([][]string{{"add", "a b"}, {"print"}, {"exit"}}, got)
+}
+
+func TestComplete(t *testing.T) {
+	assert := assert.New(t)
+	var gotArgs []string
+	var gotPartial string
+	s := &Shell{Complete: func(args []string, partial string) []string {
+		gotArgs, gotPartial = args, partial
+		return nil
+	}}
+	s.complete("add +pr")
+	assert.Equal([]string{"add"}, gotArgs)
+	assert.Equal("+pr", gotPartial)
+	s.complete("del ")
+	assert.Equal([]string{"del"}, gotArgs)
+	assert.Equal("", gotPartial)
+}
+
+func TestHistory(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "history")
+	s := &Shell{HistoryPath: path}
+	s.addHistory("one")
+	s.addHistory("one")
+	s.addHistory("two")
+	other := &Shell{HistoryPath: path}
+	other.loadHistory()
+	assert.Equal([]string{"one", "two"}, other.history)
+}
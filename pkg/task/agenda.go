@@ -0,0 +1,177 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// a moment of an occurrence within the agenda
+type agendaEntry struct {
+	occ  *Occurrence
+	at   time.Time
+	kind string // due, end, dead or r
+}
+
+// whether the event of the occurrence has started and not yet ended
+func (o *Occurrence) running() bool {
+	return o.End != nil && !o.Due.After(rightNow) && o.End.After(rightNow)
+}
+
+func (e *agendaEntry) color() string {
+	switch e.kind {
+	case "due":
+		if e.at.Before(rightNow) {
+			return "print.color-burnt"
+		}
+		if level := e.occ.Task.urgencyAt("due", &e.at); level != UrgencyNone {
+			return level.color()
+		}
+		return "print.color-date-due"
+	case "end":
+		if e.occ.running() {
+			return "print.color-running-event"
+		}
+		return "print.color-date-end"
+	case "dead":
+		if level := e.occ.Task.urgencyAt("dead", &e.at); level != UrgencyNone {
+			return level.color()
+		}
+		return "print.color-date-dead"
+	}
+	return "print.color-date-r"
+}
+
+// overdue for tasks whose due has passed and running for events in progress
+func (e *agendaEntry) mark() string {
+	switch {
+	case e.occ.running():
+		return " (running)"
+	case e.kind == "due" && e.at.Before(rightNow) && e.occ.End == nil:
+		return " (overdue)"
+	}
+	return ""
+}
+
+func (e *agendaEntry) format(idLen int) string {
+	clock := "     "
+	if at := e.at.In(LocalZone()); at.Hour() != 0 || at.Minute() != 0 {
+		clock = at.Format("15:04")
+	}
+	ref := fmt.Sprintf("%s:%0*d", e.occ.List, idLen, *e.occ.Task.ID)
+	text := occurrenceText(e.occ) + e.mark()
+	return colorize("print.color-index", "  "+clock+" ") +
+		colorize(e.color(), fmt.Sprintf("%-4s ", e.kind)) +
+		colorize("print.color-index", ref+" ") +
+		colorize(e.color(), text)
+}
+
+// the agenda of the given lists as lines; the moments of the tasks falling
+// within the given number of days from the start of from grouped by day.
+// tasks overdue before the window, or before now when it starts later,
+// are listed first and only there; the ones due earlier on the first day
+// stay under it.
+func RenderAgenda(paths []string, from time.Time, days int) ([]string, error) {
+	from = startOfDay(from)
+	to := from.AddDate(0, 0, days)
+	occs, err := Occurrences(paths, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var overdue []*agendaEntry
+	late := make(map[*Task]bool)
+	idLen := 1
+	cutoff := from
+	if rightNow.Before(from) {
+		cutoff = rightNow
+	}
+	for _, path := range paths {
+		path, _ := prepFileTaskFromPath(path)
+		for _, t := range Lists[path].Tasks {
+			if t.Time.DueDate != nil && t.Time.DueDate.Before(cutoff) && t.Time.EndDate == nil {
+				occ := &Occurrence{Task: t, List: ListName(path), Due: *t.Time.DueDate}
+				overdue = append(overdue, &agendaEntry{occ: occ, at: occ.Due, kind: "due"})
+				late[t] = true
+				idLen = max(idLen, len(strconv.Itoa(*t.ID)))
+			}
+		}
+	}
+	slices.SortStableFunc(overdue, func(l, r *agendaEntry) int {
+		return l.at.Compare(r.at)
+	})
+
+	byDay := make(map[time.Time][]*agendaEntry)
+	add := func(occ *Occurrence, at time.Time, kind string) {
+		if at.Before(from) || !at.Before(to) {
+			return
+		}
+		if kind == "due" && late[occ.Task] && at.Before(rightNow) {
+			return // already listed as overdue
+		}
+		day := startOfDay(at)
+		byDay[day] = append(byDay[day], &agendaEntry{occ: occ, at: at, kind: kind})
+		idLen = max(idLen, len(strconv.Itoa(*occ.Task.ID)))
+	}
+	for _, occ := range occs {
+		add(occ, occ.Due, "due")
+		if occ.End != nil {
+			add(occ, *occ.End, "end")
+		}
+		if occ.Dead != nil {
+			add(occ, *occ.Dead, "dead")
+		}
+		for _, r := range occ.Reminders {
+			add(occ, r, "r")
+		}
+	}
+
+	header := fmt.Sprintf("> agenda | %s — %s ", from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02"))
+	out := []string{colorize("print.color-header", header+strings.Repeat("—", max(80-utils.RuneCount(header), 0)))}
+	if len(overdue) > 0 {
+		out = append(out, colorize("print.color-burnt", "overdue"))
+		for _, e := range overdue {
+			out = append(out, e.format(idLen)+colorize("print.color-burnt", " "+e.at.In(LocalZone()).Format("2006-01-02")))
+		}
+	}
+	loads := estimateLoads(occs, from, to)
+	today := startOfDay(rightNow)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		entries := byDay[day]
+		if len(entries) == 0 {
+			continue
+		}
+		slices.SortStableFunc(entries, func(l, r *agendaEntry) int {
+			return l.at.Compare(r.at)
+		})
+		label := day.Format("Mon 02 Jan")
+		color := "print.color-header"
+		if day.Equal(today) {
+			label += " (today)"
+			color = "print.color-urgent"
+		}
+		if load, ok := loads[day]; ok {
+			label += " | est " + formatLoad(load)
+			if isOverloaded(load) {
+				label += " (overloaded)"
+				color = "print.color-overloaded"
+			}
+		}
+		out = append(out, colorize(color, label))
+		for _, e := range entries {
+			out = append(out, e.format(idLen))
+		}
+	}
+	return out, nil
+}
+
+func PrintAgenda(paths []string, from time.Time, days int) error {
+	lines, err := RenderAgenda(paths, from, days)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
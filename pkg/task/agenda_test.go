@@ -0,0 +1,68 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderAgenda(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	path, _ := parseFilepath("agenda")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("standup $c=2025-01-10T09 $due=2025-01-15T09-30 $every=1d", path))
+	require.NoError(t, AddTaskFromStr("trip $c=2025-01-10T09 $due=2025-01-14 $end=2025-01-16T12", path))
+	require.NoError(t, AddTaskFromStr("taxes $c=2025-01-10T09 $due=2025-01-16 $dead=2025-01-17T17 $r=2025-01-16T08", path))
+	require.NoError(t, AddTaskFromStr("laundry $c=2025-01-10T09 $due=2025-01-12T18", path))
+	require.NoError(t, AddTaskFromStr("someday $c=2025-01-10T09", path))
+
+	lines, err := RenderAgenda([]string{path}, rightNow, 3)
+	require.NoError(t, err)
+	assert.True(strings.HasPrefix(lines[0], "> agenda | 2025-01-15 — 2025-01-17 "))
+	assert.Equal([]string{
+		"overdue",
+		"  18:00 due  agenda:3 laundry (overdue) 2025-01-12",
+		"Wed 15 Jan (today)",
+		"  09:30 due  agenda:0 standup",
+		"Thu 16 Jan",
+		"        due  agenda:2 taxes",
+		"  08:00 r    agenda:2 taxes",
+		"  09:30 due  agenda:0 standup",
+		"  12:00 end  agenda:1 trip (running)",
+		"Fri 17 Jan",
+		"  09:30 due  agenda:0 standup",
+		"  17:00 dead agenda:2 taxes",
+	}, lines[1:])
+
+	t.Run("window", func(t *testing.T) {
+		lines, err := RenderAgenda([]string{path}, time.Date(2025, 1, 20, 15, 0, 0, 0, time.Local), 1)
+		require.NoError(t, err)
+		assert.True(strings.HasPrefix(lines[0], "> agenda | 2025-01-20 — 2025-01-20 "))
+		assert.Equal([]string{ // only what is overdue now, not by the start of the window
+			"overdue",
+			"  18:00 due  agenda:3 laundry (overdue) 2025-01-12",
+		}, lines[1:3])
+		assert.Equal("Mon 20 Jan", lines[len(lines)-2])
+		assert.Equal("  09:30 due  agenda:0 standup", lines[len(lines)-1])
+	})
+	t.Run("today", func(t *testing.T) {
+		path, _ := parseFilepath("agenda-today")
+		Lists.Empty(path)
+		require.NoError(t, AddTaskFromStr("early $c=2025-01-10T09 $due=2025-01-15T02", path))
+		require.NoError(t, AddTaskFromStr("daily $c=2025-01-10T09 $due=2025-01-14T08 $every=1d", path))
+		lines, err := RenderAgenda([]string{path}, rightNow, 1)
+		require.NoError(t, err)
+		assert.Equal([]string{ // each overdue task once
+			"overdue",
+			"  08:00 due  agenda-today:1 daily (overdue) 2025-01-14",
+			"Wed 15 Jan (today)",
+			"  02:00 due  agenda-today:0 early (overdue)",
+		}, lines[1:])
+	})
+}
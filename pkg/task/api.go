@@ -7,6 +7,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 )
 
 func cleanupIDs(path string) error {
@@ -61,6 +62,7 @@ func cleanupRelations(path string) error {
 	Lists[path].EIDs = make(map[string]*Task)
 	Lists[path].PIDs = make(map[*Task]string)
 	for _, task := range Lists[path].Tasks {
+		task.list = path
 		if task.EID != nil {
 			_, ok := Lists[path].EIDs[*task.EID]
 			if ok {
@@ -142,6 +144,19 @@ func AddTaskFromStr(task, path string) error {
 	return AddTask(t, path)
 }
 
+// the id of the last task of the list; e.g. the one just added
+func LastID(path string) (int, error) {
+	path, err := prepFileTaskFromPath(path)
+	if err != nil {
+		return -1, err
+	}
+	n := Lists.Len(path)
+	if n == 0 {
+		return -1, fmt.Errorf("%w: list '%s' is empty", terrors.ErrNotFound, ListName(path))
+	}
+	return *Lists[path].Tasks[n-1].ID, nil
+}
+
 func getTaskIndexFromId(id int, path string) (int, error) {
 	path, err := prepFileTaskFromPath(path)
 	if err != nil {
@@ -171,6 +186,10 @@ func getTaskFromId(id int, path string) (*Task, error) {
 	return Lists[path].Tasks[taskNdx], nil
 }
 
+func GetTask(id int, path string) (*Task, error) {
+	return getTaskFromId(id, path)
+}
+
 func AppendToTask(id int, text, path string) error {
 	task, err := getTaskFromId(id, path)
 	if err != nil {
@@ -350,7 +369,18 @@ func DeleteTasks(ids []int, path string) error {
 	return nil
 }
 
+// recurring tasks are logged to the done file and advanced
+// to their next occurrence instead of being removed.
 func DoneTask(ids []int, path string) error {
+	return doneTasks(ids, path, false)
+}
+
+// removes the tasks for good, ending the series of recurring ones.
+func FinishTask(ids []int, path string) error {
+	return doneTasks(ids, path, true)
+}
+
+func doneTasks(ids []int, path string, finish bool) error {
 	path, err := prepFileTaskFromPath(path)
 	if err != nil {
 		return err
@@ -359,23 +389,21 @@ func DoneTask(ids []int, path string) error {
 	if err != nil {
 		return err
 	}
-	var tasks []*Task
+	var out []string
 	for _, ndx := range indexes {
-		tasks = append(tasks, Lists[path].Tasks[ndx])
+		task := Lists[path].Tasks[ndx]
+		if !finish {
+			if instance, ok := task.advance(); ok {
+				out = append(out, instance)
+				continue
+			}
+		}
 		Lists.DeleteTasks(path, ndx, ndx+1)
+		task.unfocus()
+		out = append(out, task.Raw())
 	}
 	cleanupIDs(path)
 	cleanupRelations(path)
-
-	var out []string
-	for _, task := range tasks {
-		if task.Fmt != nil && task.Fmt.Focus {
-			task.Fmt.Focus = false
-			_, ndx := task.Tokens.Find(TkByTypeKey(TokenFormat, "focus"))
-			task.Tokens = slices.Delete(task.Tokens, ndx, ndx+1)
-		}
-		out = append(out, task.Raw())
-	}
 	return appendToDoneFile(strings.Join(out, "\n"), path)
 }
 
@@ -448,19 +476,7 @@ func IncrementProgressCount(id int, path string, value int) error {
 	if task.Prog == nil {
 		return fmt.Errorf("%w: task with id '%d' has no progress", terrors.ErrValue, id)
 	}
-	rVal := task.Prog.Count + value
-	task.Prog.Count = max(min(rVal, task.Prog.DoneCount), 0)
-	progText, err := unparseProgress(*task.Prog)
-	if err != nil {
-		return err
-	}
-	progText = fmt.Sprintf("$p=%s", progText)
-	pToken, _ := task.Tokens.Find(TkByType(TokenProgress))
-	if pToken == nil {
-		return fmt.Errorf("%w: task with id '%d' has no progress", terrors.ErrValue, id)
-	}
-	*pToken.raw = progText
-	return nil
+	return setProgressCount(task, path, task.Prog.Count+value)
 }
 
 func SetProgressCount(id int, path string, value int) error {
@@ -475,6 +491,12 @@ func SetProgressCount(id int, path string, value int) error {
 	if task.Prog == nil {
 		return fmt.Errorf("%w: task with id '%d' has no progress", terrors.ErrValue, id)
 	}
+	return setProgressCount(task, path, value)
+}
+
+// clamps the count into the range of the progress and logs the change
+func setProgressCount(task *Task, path string, value int) error {
+	prev := task.Prog.Count
 	task.Prog.Count = max(min(value, task.Prog.DoneCount), 0)
 	progText, err := unparseProgress(*task.Prog)
 	if err != nil {
@@ -483,46 +505,49 @@ func SetProgressCount(id int, path string, value int) error {
 	progText = fmt.Sprintf("$p=%s", progText)
 	pToken, _ := task.Tokens.Find(TkByType(TokenProgress))
 	if pToken == nil {
-		return fmt.Errorf("%w: task with id '%d' has no progress", terrors.ErrValue, id)
+		return fmt.Errorf("%w: task with id '%d' has no progress", terrors.ErrValue, *task.ID)
 	}
 	*pToken.raw = progText
-	return nil
+	return recordProgress(task, path, prev)
 }
 
+// overdue recurring tasks are moved on to their next occurrence, their other
+// dates shifted along as on done;
+// those whose series is exhausted are retired to the done file.
 func CheckAndRecurTasks(path string) error {
 	path, err := prepFileTaskFromPath(path)
 	if err != nil {
 		return err
 	}
-	for _, task := range Lists[path].Tasks {
-		if task.Time.Every != nil &&
-			task.Time.DueDate != nil &&
+	var retired []int
+	for ndx, task := range Lists[path].Tasks {
+		if task.Time.DueDate != nil &&
 			task.Time.DueDate.Before(rightNow) {
 
-			newDt := *task.Time.DueDate
-			for newDt.Before(rightNow) {
-				newDt = newDt.Add(*task.Time.Every)
-			}
-			diff := newDt.Sub(*task.Time.DueDate) // must be before update!
-			err := task.updateDate("due", &newDt)
-			if err != nil {
-				return err
-			}
-
-			if task.Time.EndDate != nil && task.Time.EndDate.Before(rightNow) {
-				err = task.updateDate("end", utils.MkPtr(task.Time.EndDate.Add(diff)))
-				if err != nil {
-					return err
-				}
-			} else if task.Time.Deadline != nil && task.Time.Deadline.Before(rightNow) {
-				err = task.updateDate("dead", utils.MkPtr(task.Time.Deadline.Add(diff)))
-				if err != nil {
-					return err
+			newDt, passed, ok := task.Time.nextBoundedOccurrence(*task.Time.DueDate, rightNow)
+			if !ok {
+				if task.recurs() {
+					retired = append(retired, ndx)
 				}
+				continue
 			}
+			task.consumeTimes(passed)
+			task.shiftDates(newDt.Sub(*task.Time.DueDate))
 		}
 	}
-	return nil
+	if len(retired) == 0 {
+		return nil
+	}
+	var out []string
+	for _, ndx := range slices.Backward(retired) {
+		task := Lists[path].Tasks[ndx]
+		Lists.DeleteTasks(path, ndx, ndx+1)
+		task.unfocus()
+		out = append(out, task.Raw())
+	}
+	cleanupIDs(path)
+	cleanupRelations(path)
+	return appendToDoneFile(strings.Join(out, "\n"), path)
 }
 
 func ToggleCollapsed(id int, path string) error {
@@ -546,6 +571,45 @@ func ToggleCollapsed(id int, path string) error {
 	return nil
 }
 
+func ToggleFocus(id int, path string) error {
+	path, err := prepFileTaskFromPath(path)
+	if err != nil {
+		return err
+	}
+	task, err := getTaskFromId(id, path)
+	if err != nil {
+		return err
+	}
+	if task.Fmt != nil && task.Fmt.Focus {
+		task.unfocus()
+		return nil
+	}
+	if task.Fmt == nil {
+		task.Fmt = new(Format)
+	}
+	task.Fmt.Focus = true
+	task.Tokens = append(task.Tokens, &Token{
+		Type: TokenFormat, Key: "focus",
+		raw: utils.MkPtr("$focus"),
+	})
+	return nil
+}
+
+// hides the task until the given time by replacing its threshold
+func SnoozeTask(id int, until time.Time, path string) error {
+	task, err := getTaskFromId(id, path)
+	if err != nil {
+		return err
+	}
+	if !until.After(rightNow) {
+		return fmt.Errorf("%w: snooze must end in the future not '%s'", terrors.ErrValue, unparseAbsoluteDatetime(until))
+	}
+	if _, ndx := task.Tokens.Find(TkByTypeKey(TokenDate, "t")); ndx != -1 {
+		task.Tokens = slices.Delete(task.Tokens, ndx, ndx+1)
+	}
+	return task.updateByModifyingText("", fmt.Sprintf("$t=%s", unparseAbsoluteDatetime(until)))
+}
+
 func SortList(path string) error {
 	path, err := prepFileTaskFromPath(path)
 	if err != nil {
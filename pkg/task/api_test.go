@@ -388,6 +388,64 @@ func TestDoneTask(t *testing.T) {
 	assert.True(strings.HasPrefix(tasks[1], "2 $id=2"))
 }
 
+func TestDoneRecurringTask(t *testing.T) {
+	assert := assert.New(t)
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	path, _ := parseFilepath("file")
+	Lists.Empty(path)
+	AddTaskFromStr("0 $c=2024-05-05T05-05 $due=1d $end=2h $r=-1h $every=1w", path)
+	AddTaskFromStr("1 $due=1d $every=1w $focus", path)
+	AddTaskFromStr("2 $due=1d", path)
+	AddTaskFromStr("3 $due=1d $every=1w", path)
+	due0 := *Lists[path].Tasks[0].Time.DueDate
+	due1 := *Lists[path].Tasks[1].Time.DueDate
+
+	err = DoneTask([]int{0, 1, 2}, path)
+	require.NoError(t, err)
+	require.Equal(t, 3, Lists.Len(path))
+
+	t.Run("past due", func(t *testing.T) {
+		task := Lists[path].Tasks[0]
+		assert.True(task.Time.DueDate.After(rightNow))
+		assert.Equal(time.Duration(0), task.Time.DueDate.Sub(due0)%(7*24*time.Hour))
+		assert.Equal(task.Time.DueDate.Add(2*time.Hour), *task.Time.EndDate)
+		if assert.Len(task.Time.Reminders, 1) {
+			assert.Equal(task.Time.DueDate.Add(-time.Hour), *task.Time.Reminders[0])
+		}
+		tk, _ := task.Tokens.Find(TkByTypeKey(TokenDate, "end"))
+		assert.Equal("$end=2h", *tk.raw)
+		tk, _ = task.Tokens.Find(TkByTypeKey(TokenDate, "r"))
+		assert.Equal("$r=-1h", *tk.raw)
+	})
+	t.Run("future due", func(t *testing.T) {
+		task := Lists[path].Tasks[1]
+		assert.Equal(due1.Add(7*24*time.Hour), *task.Time.DueDate)
+		assert.True(task.Fmt.Focus)
+		assert.Equal("3 $due=1d $every=1w", Lists[path].Tasks[2].Norm())
+	})
+	t.Run("done file", func(t *testing.T) {
+		raw, err := os.ReadFile(filepath.Join(todosDir(), "_etc", "file.done"))
+		require.NoError(t, err)
+		tasks := strings.Split(string(raw), "\n")
+		require.Len(t, tasks, 3)
+		assert.True(strings.HasPrefix(tasks[0], "2 $due=1d"))
+		assert.True(strings.HasPrefix(tasks[1], "1 $due=1d $every=1w"))
+		assert.NotContains(tasks[1], "$focus")
+		assert.True(strings.HasPrefix(tasks[2], "0 $c=2024-05-05T05-05 $due=1d $end=2h $r=-1h"))
+	})
+	t.Run("finish", func(t *testing.T) {
+		err := FinishTask([]int{2}, path)
+		require.NoError(t, err)
+		assert.Equal(2, Lists.Len(path))
+	})
+}
+
 func TestMoveTask(t *testing.T) {
 	assert := assert.New(t)
 	path, _ := parseFilepath("src")
@@ -726,6 +784,27 @@ func TestToggleCollapsed(t *testing.T) {
 	assert.True(Lists[path].Tasks[1].IsCollapsed())
 }
 
+func TestToggleFocus(t *testing.T) {
+	assert := assert.New(t)
+	path, _ := parseFilepath("toggleF")
+	Lists.Empty(path)
+	AddTaskFromStr("was focused $focus", path)
+	AddTaskFromStr("was not", path)
+
+	err := ToggleFocus(0, path)
+	assert.NoError(err)
+	assert.False(Lists[path].Tasks[0].Fmt.Focus)
+	assert.Equal("was focused", Lists[path].Tasks[0].Norm())
+
+	err = ToggleFocus(1, path)
+	assert.NoError(err)
+	assert.True(Lists[path].Tasks[1].Fmt.Focus)
+	assert.Equal("was not $focus", Lists[path].Tasks[1].Norm())
+
+	err = ToggleFocus(2, path)
+	assert.ErrorIs(err, terrors.ErrNotFound)
+}
+
 func TestSortList(t *testing.T) {
 	assert := assert.New(t)
 	path, _ := parseFilepath("sortList")
@@ -784,3 +863,24 @@ func TestSortList(t *testing.T) {
 		assert.Equal(tasks[ndx].Raw(), Lists[path].Tasks[ndx].Raw())
 	}
 }
+
+func TestSnoozeTask(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	path, _ := parseFilepath("snooze")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("prep $c=2025-01-10T09 $due=2025-01-20T09 $t=due:-2d", path))
+	require.NoError(t, AddTaskFromStr("idle $c=2025-01-10T09", path))
+
+	until := time.Date(2025, 1, 19, 9, 0, 0, 0, time.Local)
+	require.NoError(t, SnoozeTask(0, until, path))
+	require.NoError(t, SnoozeTask(1, until, path))
+	for _, task := range Lists[path].Tasks {
+		assert.Equal(until, *task.Time.Threshold)
+		assert.True(task.IsDeferred())
+		assert.Len(*task.Tokens.Filter(TkByTypeKey(TokenDate, "t")), 1)
+	}
+	assert.Equal("prep $c=2025-01-10T09 $due=2025-01-20T09 $t=2025-01-19T09", Lists[path].Tasks[0].Raw())
+	assert.ErrorIs(SnoozeTask(1, rightNow, path), terrors.ErrValue)
+}
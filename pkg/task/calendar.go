@@ -0,0 +1,202 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// the most items shown in a day of the month view
+const calMonthItems = 3
+
+// a line within a day of the calendar
+type calItem struct {
+	text  string
+	color string
+}
+
+// "15:04 " or nothing at midnight
+func formatClock(dt time.Time) string {
+	dt = dt.In(LocalZone())
+	if dt.Hour() == 0 && dt.Minute() == 0 {
+		return ""
+	}
+	return dt.Format("15:04 ")
+}
+
+func occurrenceText(o *Occurrence) string {
+	if text := strings.TrimSpace(o.Task.NormRegular()); text != "" {
+		return text
+	}
+	return o.Task.Norm()
+}
+
+// the items of a single day; tasks due that day, events running through it
+// and deadlines falling on it
+func calendarDay(occs []*Occurrence, day time.Time) []calItem {
+	next := day.AddDate(0, 0, 1)
+	within := func(dt *time.Time) bool {
+		return dt != nil && !dt.Before(day) && dt.Before(next)
+	}
+	var out []calItem
+	for _, o := range occs {
+		if !o.overlaps(day, next) {
+			continue
+		}
+		text := occurrenceText(o)
+		if within(&o.Due) {
+			color := "print.color-date-due"
+			if level := o.Task.urgencyAt("due", &o.Due); level != UrgencyNone {
+				color = level.color()
+			}
+			if o.Due.Before(rightNow) {
+				color = "print.color-burnt"
+			} else if o.End != nil {
+				color = "print.color-running-event"
+			}
+			out = append(out, calItem{formatClock(o.Due) + text, color})
+		} else if o.End != nil && o.Due.Before(day) && !o.End.Before(day) {
+			prefix := "→ "
+			if within(o.End) {
+				prefix = "→" + formatClock(*o.End)
+			}
+			out = append(out, calItem{prefix + text, "print.color-running-event-text"})
+		}
+		if within(o.Dead) {
+			color := "print.color-date-dead"
+			if level := o.Task.urgencyAt("dead", o.Dead); level != UrgencyNone {
+				color = level.color()
+			}
+			out = append(out, calItem{"!" + formatClock(*o.Dead) + text, color})
+		}
+	}
+	return out
+}
+
+// pads or truncates the text to exactly width runes
+func calendarCell(text string, width int) string {
+	n := utils.RuneCount(text)
+	if n > width {
+		return utils.RuneSlice(text, 0, width-1) + "…"
+	}
+	return text + strings.Repeat(" ", width-n)
+}
+
+// a row of the grid; one cell per day joined by the separator
+func calendarRow(cells []string, colors []string, width int) string {
+	var out strings.Builder
+	for ndx, cell := range cells {
+		if ndx > 0 {
+			out.WriteString(colorize("print.color-index", "│"))
+		}
+		out.WriteString(colorize(colors[ndx], calendarCell(cell, width)))
+	}
+	return out.String()
+}
+
+// the lines of a row of days; the first one holds the labels of the days.
+// at most maxItems lines are given to the items of a day if maxItems > 0.
+func calendarWeek(occs []*Occurrence, days []time.Time, label func(time.Time) string,
+	hidden func(time.Time) bool, width, maxItems int) []string {
+	items := make([][]calItem, len(days))
+	height := 0
+	for ndx, day := range days {
+		if !hidden(day) {
+			items[ndx] = calendarDay(occs, day)
+		}
+		height = max(height, len(items[ndx]))
+	}
+	if maxItems > 0 && height > maxItems {
+		height = maxItems + 1
+	}
+	today := startOfDay(rightNow)
+	cells, colors := make([]string, len(days)), make([]string, len(days))
+	for ndx, day := range days {
+		cells[ndx], colors[ndx] = label(day), "print.color-header"
+		if day.Equal(today) {
+			colors[ndx] = "print.color-urgent"
+		} else if hidden(day) {
+			colors[ndx] = "print.color-hidden"
+		}
+	}
+	out := []string{calendarRow(cells, colors, width)}
+	for line := range height {
+		for ndx := range days {
+			cells[ndx], colors[ndx] = "", "print.color-default"
+			dayItems := items[ndx]
+			if maxItems > 0 && line == maxItems && len(dayItems) > maxItems {
+				cells[ndx] = fmt.Sprintf("+%d more", len(dayItems)-maxItems)
+				colors[ndx] = "print.color-hidden"
+			} else if line < len(dayItems) {
+				cells[ndx], colors[ndx] = dayItems[line].text, dayItems[line].color
+			}
+		}
+		out = append(out, calendarRow(cells, colors, width))
+	}
+	return out
+}
+
+// the calendar of the given lists as lines; the week around day, or its
+// month if month is set. weeks begin on start and span width runes.
+func RenderCalendar(paths []string, day time.Time, month bool, start time.Weekday, width int) ([]string, error) {
+	cellWidth := max((width-6)/7, 8)
+	width = cellWidth*7 + 6
+	day = startOfDay(day)
+	first, last := day, day
+	if month {
+		first = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		last = first.AddDate(0, 1, -1)
+	}
+	first = first.AddDate(0, 0, -((int(first.Weekday()) - int(start) + 7) % 7))
+	last = last.AddDate(0, 0, (int(start)-int(last.Weekday())+6)%7)
+
+	occs, err := Occurrences(paths, first, last.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	var header string
+	if month {
+		header = day.Format("2006-01")
+	} else {
+		header = first.Format("2006-01-02") + " — " + last.Format("2006-01-02")
+	}
+	header = "> cal | " + header + " "
+	out := []string{colorize("print.color-header", header+strings.Repeat("—", max(width-utils.RuneCount(header), 0)))}
+	if !month {
+		var days []time.Time
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+		label := func(d time.Time) string { return d.Format("Mon 02") }
+		never := func(time.Time) bool { return false }
+		return append(out, calendarWeek(occs, days, label, never, cellWidth, 0)...), nil
+	}
+
+	var names []string
+	for ndx := range 7 {
+		names = append(names, time.Weekday((int(start) + ndx) % 7).String()[:3])
+	}
+	out = append(out, calendarRow(names, make([]string, 7), cellWidth))
+	label := func(d time.Time) string { return d.Format("02") }
+	outside := func(d time.Time) bool { return d.Month() != day.Month() }
+	for sow := first; !sow.After(last); sow = sow.AddDate(0, 0, 7) {
+		days := make([]time.Time, 7)
+		for ndx := range days {
+			days[ndx] = sow.AddDate(0, 0, ndx)
+		}
+		out = append(out, colorize("print.color-index", strings.Repeat("─", width)))
+		out = append(out, calendarWeek(occs, days, label, outside, cellWidth, calMonthItems)...)
+	}
+	return out, nil
+}
+
+func PrintCalendar(paths []string, day time.Time, month bool, start time.Weekday, width int) error {
+	lines, err := RenderCalendar(paths, day, month, start, width)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
@@ -0,0 +1,76 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCalendar(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	path, _ := parseFilepath("calendar")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("standup $c=2025-01-15T09 $due=2025-01-16T09-30 $every=1d", path))
+	require.NoError(t, AddTaskFromStr("trip $c=2025-01-15T09 $due=2025-01-17 $end=2025-01-19T12", path))
+	require.NoError(t, AddTaskFromStr("taxes $c=2025-01-15T09 $due=2025-01-16 $dead=2025-01-18T17", path))
+
+	t.Run("week", func(t *testing.T) {
+		lines, err := RenderCalendar([]string{path}, rightNow, false, time.Saturday, 62)
+		require.NoError(t, err)
+		assert.True(strings.HasPrefix(lines[0], "> cal | 2025-01-11 — 2025-01-17 "))
+		cells := strings.Split(lines[1], "│")
+		require.Len(t, cells, 7)
+		assert.Equal("Sat 11  ", cells[0])
+		assert.Equal("Fri 17  ", cells[6])
+		var thu, fri []string
+		for _, line := range lines[2:] {
+			cells := strings.Split(line, "│")
+			thu = append(thu, strings.TrimSpace(cells[5]))
+			fri = append(fri, strings.TrimSpace(cells[6]))
+		}
+		assert.Equal([]string{"taxes", "09:30 s…"}, thu)
+		assert.Equal([]string{"trip", "09:30 s…"}, fri)
+	})
+	t.Run("events and deadlines", func(t *testing.T) {
+		day := time.Date(2025, 1, 18, 0, 0, 0, 0, time.Local)
+		var texts []string
+		occs, err := Occurrences([]string{path}, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		for _, item := range calendarDay(occs, day) {
+			texts = append(texts, item.text)
+		}
+		assert.Equal([]string{"!17:00 taxes", "→ trip", "09:30 standup"}, texts)
+		texts = nil
+		day = day.AddDate(0, 0, 1)
+		occs, _ = Occurrences([]string{path}, day, day.AddDate(0, 0, 1))
+		for _, item := range calendarDay(occs, day) {
+			texts = append(texts, item.text)
+		}
+		assert.Equal([]string{"→12:00 trip", "09:30 standup"}, texts)
+	})
+	t.Run("month", func(t *testing.T) {
+		for _, line := range []string{"a $due=2025-01-21T10", "b $due=2025-01-21T11", "c $due=2025-01-21T12"} {
+			require.NoError(t, AddTaskFromStr(line+" $c=2025-01-15T09", path))
+		}
+		lines, err := RenderCalendar([]string{path}, rightNow, true, time.Monday, 62)
+		require.NoError(t, err)
+		assert.True(strings.HasPrefix(lines[0], "> cal | 2025-01 "))
+		assert.True(strings.HasPrefix(lines[1], "Mon     │Tue"))
+		// five weeks from dec 30th to feb 2nd; each one with a separator and a label row
+		var labels []string
+		for _, line := range lines[2:] {
+			if cells := strings.Split(line, "│"); len(cells) == 7 && strings.TrimSpace(cells[0]) != "" &&
+				len(strings.TrimSpace(cells[0])) == 2 {
+				labels = append(labels, strings.TrimSpace(cells[0]))
+			}
+		}
+		assert.Equal([]string{"30", "06", "13", "20", "27"}, labels)
+		assert.Contains(strings.Join(lines, "\n"), "│+1 more │")
+	})
+}
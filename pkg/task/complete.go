@@ -0,0 +1,102 @@
+package task
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// the completion candidates for special tokens alongside an example and a description
+var tokenCompletions = []struct {
+	key     string
+	example string
+	desc    string
+}{
+	{"$id=", "$id=name", "explicit id for parenting"},
+	{"$-id=", "$-id=name", "explicit id with collapsed children"},
+	{"$P=", "$P=name", "parent id"},
+	{"$c=", "$c=2025-05-05T10-30", "creation datetime"},
+	{"$due=", "$due=1w", "due datetime"},
+	{"$end=", "$end=due:2h", "end datetime of an event"},
+	{"$dead=", "$dead=due:3d", "deadline"},
+	{"$r=", "$r=-1d", "reminder; may be repeated"},
+	{"$every=", "$every=1w", "recurrence duration"},
+	{"$est=", "$est=1h", "effort estimate"},
+	{"$rec=", "$rec=monthly:-1fri", "calendar recurrence: freq[/interval][:spec]"},
+	{"$until=", "$until=3m", "end of a recurring series"},
+	{"$times=", "$times=10", "remaining occurrences of a recurring series"},
+	{"$t=", "$t=due:-2d", "threshold; hidden until then"},
+	{"$p=", "$p=page/0/300/books", "progress: unit/count/doneCount[/category]"},
+	{"$mit=", "$mit=1", "most important task rank"},
+	{"$focus", "$focus", "focus this task"},
+	{"$urgent", "$urgent", "mark as urgent"},
+}
+
+// the name of the list relative to the todos directory
+func ListName(path string) string {
+	if rel, err := filepath.Rel(todosDir(), path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return filepath.Base(path)
+}
+
+// the names of all lists relative to the todos directory
+func ListNames() ([]string, error) {
+	paths, err := LsFiles()
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, path := range paths {
+		out = append(out, ListName(path))
+	}
+	return out, nil
+}
+
+// "<id>\t<text>" candidates of a loaded list
+func TaskCompletions(path string) ([]string, error) {
+	path, err := prepFileTaskFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, task := range Lists[path].Tasks {
+		if task.ID == nil {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%d\t%s", *task.ID, task.Norm()))
+	}
+	return out, nil
+}
+
+// the distinct hints of all loaded lists which start with prefix
+func HintCompletions(prefix string) []string {
+	seen := make(map[string]bool)
+	for _, list := range Lists {
+		for _, task := range list.Tasks {
+			for _, hint := range task.Hints {
+				if strings.HasPrefix(*hint, prefix) {
+					seen[*hint] = true
+				}
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for hint := range seen {
+		out = append(out, hint)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// "<$key=>\t<description>" candidates of special tokens which start with prefix
+func TokenCompletions(prefix string) []string {
+	var out []string
+	for _, tc := range tokenCompletions {
+		if strings.HasPrefix(tc.key, prefix) {
+			out = append(out, fmt.Sprintf("%s\t%s; e.g. %s", tc.key, tc.desc, tc.example))
+		}
+	}
+	return out
+}
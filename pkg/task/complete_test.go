@@ -0,0 +1,54 @@
+package task
+
+import (
+	"dotxt/config"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListNames(t *testing.T) {
+	assert := assert.New(t)
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	CreateFile(filepath.Join(tmpDir, "todos", "todo"))
+	CreateFile(filepath.Join(tmpDir, "todos", "nested", "file"))
+	names, err := ListNames()
+	require.NoError(t, err)
+	assert.ElementsMatch([]string{"todo", "nested/file"}, names)
+	assert.Equal("other", ListName("/elsewhere/other"))
+}
+
+func TestCompletions(t *testing.T) {
+	assert := assert.New(t)
+	path, _ := parseFilepath("completions")
+	Lists = make(lists)
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("first +proj @home", path))
+	require.NoError(t, AddTaskFromStr("second +prog #tag", path))
+
+	t.Run("tasks", func(t *testing.T) {
+		out, err := TaskCompletions(path)
+		require.NoError(t, err)
+		assert.Equal([]string{"0\tfirst +proj @home", "1\tsecond +prog #tag"}, out)
+	})
+	t.Run("hints", func(t *testing.T) {
+		assert.Equal([]string{"+prog", "+proj"}, HintCompletions("+pro"))
+		assert.Equal([]string{"@home"}, HintCompletions("@h"))
+		assert.Empty(HintCompletions("#nothing"))
+	})
+	t.Run("tokens", func(t *testing.T) {
+		out := TokenCompletions("$d")
+		require.Len(t, out, 2)
+		assert.Equal("$due=\tdue datetime; e.g. $due=1w", out[0])
+		assert.Equal("$dead=\tdeadline; e.g. $dead=due:3d", out[1])
+		assert.Len(TokenCompletions("$"), len(tokenCompletions))
+	})
+}
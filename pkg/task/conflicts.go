@@ -0,0 +1,155 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// two events whose spans overlap from From to To
+type Conflict struct {
+	A, B     *Occurrence
+	From, To time.Time
+}
+
+// the events among the occurrences; the ones with an end after their due
+func events(occs []*Occurrence) []*Occurrence {
+	var out []*Occurrence
+	for _, occ := range occs {
+		if occ.End != nil && occ.End.After(occ.Due) {
+			out = append(out, occ)
+		}
+	}
+	slices.SortStableFunc(out, func(l, r *Occurrence) int {
+		return l.Due.Compare(r.Due)
+	})
+	return out
+}
+
+// the overlapping events of the given lists within [from, to); recurring
+// events are expanded and the occurrences of a single series never conflict.
+func FindConflicts(paths []string, from, to time.Time) ([]*Conflict, error) {
+	occs, err := Occurrences(paths, from, to)
+	if err != nil {
+		return nil, err
+	}
+	evs := events(occs)
+	var out []*Conflict
+	for i, a := range evs {
+		for _, b := range evs[i+1:] {
+			if !b.Due.Before(*a.End) {
+				break
+			}
+			if a.Task == b.Task {
+				continue
+			}
+			c := &Conflict{A: a, B: b, From: b.Due, To: *a.End}
+			if b.End.Before(c.To) {
+				c.To = *b.End
+			}
+			if c.From.Before(to) && c.To.After(from) {
+				out = append(out, c)
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(l, r *Conflict) int {
+		return l.From.Compare(r.From)
+	})
+	return out, nil
+}
+
+// the conflicts of the task with the events of the loaded lists within
+// the given number of days from the start of its due date
+func TaskConflicts(id int, path string, days int) ([]*Conflict, error) {
+	t, err := getTaskFromId(id, path)
+	if err != nil {
+		return nil, err
+	}
+	if t.Time.DueDate == nil || t.Time.EndDate == nil {
+		return nil, nil
+	}
+	from := startOfDay(*t.Time.DueDate)
+	to := from.AddDate(0, 0, days)
+	var paths []string
+	for p := range Lists {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	conflicts, err := FindConflicts(paths, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var out []*Conflict
+	for _, c := range conflicts {
+		if c.B.Task == t { // the task is always placed first
+			c.A, c.B = c.B, c.A
+		}
+		if c.A.Task == t {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func formatConflictEvent(o *Occurrence, idLen int) string {
+	return colorize("print.color-index", fmt.Sprintf("%s:%0*d ", o.List, idLen, *o.Task.ID)) +
+		colorize("print.color-default", occurrenceText(o))
+}
+
+// "Mon 20 Oct 10:00-11:00"; the overlap of the conflict
+func formatConflictSpan(c *Conflict) string {
+	from, to := c.From.In(LocalZone()), c.To.In(LocalZone())
+	out := from.Format("Mon 02 Jan 15:04") + "-"
+	if startOfDay(from).Equal(startOfDay(to)) {
+		return out + to.Format("15:04")
+	}
+	return out + to.Format("Mon 02 Jan 15:04")
+}
+
+// the conflicts of the given lists within the given number of days from the start of from
+func RenderConflicts(paths []string, from time.Time, days int) ([]string, error) {
+	from = startOfDay(from)
+	to := from.AddDate(0, 0, days)
+	conflicts, err := FindConflicts(paths, from, to)
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("> conflicts | %s — %s ", from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02"))
+	out := []string{colorize("print.color-header", header+strings.Repeat("—", max(80-utils.RuneCount(header), 0)))}
+	idLen := 1
+	for _, c := range conflicts {
+		idLen = max(idLen, len(fmt.Sprint(*c.A.Task.ID)), len(fmt.Sprint(*c.B.Task.ID)))
+	}
+	for _, c := range conflicts {
+		out = append(out,
+			colorize("print.color-overdue", formatConflictSpan(c)),
+			"  "+formatConflictEvent(c.A, idLen),
+			"  "+formatConflictEvent(c.B, idLen),
+		)
+	}
+	if len(conflicts) == 0 {
+		out = append(out, colorize("print.color-hidden", "no conflicts"))
+	}
+	return out, nil
+}
+
+func PrintConflicts(paths []string, from time.Time, days int) error {
+	lines, err := RenderConflicts(paths, from, days)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
+
+// the conflicts of the task as warnings; one line each
+func FormatConflictWarnings(conflicts []*Conflict) []string {
+	var out []string
+	for _, c := range conflicts {
+		out = append(out, fmt.Sprintf("warning: overlaps %s:%d %s on %s",
+			c.B.List, *c.B.Task.ID, occurrenceText(c.B), formatConflictSpan(c)))
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflicts(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 1, day, hour, minute, 0, 0, time.Local)
+	}
+
+	work, _ := parseFilepath("conflicts-work")
+	home, _ := parseFilepath("conflicts-home")
+	Lists.Empty(work)
+	Lists.Empty(home)
+	for _, line := range []string{
+		"standup $c=2025-01-10 $due=2025-01-16T09 $end=2025-01-16T10 $every=1d",
+		"review $c=2025-01-10 $due=2025-01-17T09-30 $end=2025-01-17T11",
+		"report $c=2025-01-10 $due=2025-01-16T12",
+	} {
+		require.NoError(t, AddTaskFromStr(line, work))
+	}
+	for _, line := range []string{
+		"dentist $c=2025-01-10 $due=2025-01-17T10-30 $end=2025-01-17T12",
+		"lunch $c=2025-01-10 $due=2025-01-16T12 $end=2025-01-16T13",
+	} {
+		require.NoError(t, AddTaskFromStr(line, home))
+	}
+
+	conflicts, err := FindConflicts([]string{work, home}, at(15, 0, 0), at(22, 0, 0))
+	require.NoError(t, err)
+	type pair struct{ a, b string }
+	var pairs []pair
+	var spans []string
+	for _, c := range conflicts {
+		pairs = append(pairs, pair{c.A.Task.NormRegular(), c.B.Task.NormRegular()})
+		spans = append(spans, formatConflictSpan(c))
+	}
+	assert.Equal([]pair{{"standup", "review"}, {"review", "dentist"}}, pairs)
+	assert.Equal([]string{"Fri 17 Jan 09:30-10:00", "Fri 17 Jan 10:30-11:00"}, spans)
+
+	conflicts, err = TaskConflicts(1, work, 30)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+	assert.Equal("review", conflicts[0].A.Task.NormRegular())
+	assert.Equal("standup", conflicts[0].B.Task.NormRegular())
+	assert.Equal([]string{
+		"warning: overlaps conflicts-work:0 standup on Fri 17 Jan 09:30-10:00",
+		"warning: overlaps conflicts-home:0 dentist on Fri 17 Jan 10:30-11:00",
+	}, FormatConflictWarnings(conflicts))
+
+	conflicts, err = TaskConflicts(2, work, 30) // not an event
+	require.NoError(t, err)
+	assert.Empty(conflicts)
+}
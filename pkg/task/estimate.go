@@ -0,0 +1,99 @@
+package task
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// the estimate of the task along with the ones of its descendants
+func (t *Task) TotalEstimate() time.Duration {
+	var total time.Duration
+	if t.Est != nil {
+		total = *t.Est
+	}
+	for _, child := range t.Children {
+		total += child.TotalEstimate()
+	}
+	return total
+}
+
+// 'time.capacity'; the effort a single day can hold. zero means no limit
+func dailyCapacity() time.Duration {
+	val := viper.GetString("time.capacity")
+	if val == "" {
+		return 0
+	}
+	dur, err := parseDuration(val)
+	if err != nil {
+		return 0
+	}
+	return *dur
+}
+
+func isOverloaded(load time.Duration) bool {
+	capacity := dailyCapacity()
+	return capacity > 0 && load > capacity
+}
+
+// "5h/8h"; the load against the capacity if there is one
+func formatLoad(load time.Duration) string {
+	out := unparseDuration(load)
+	if capacity := dailyCapacity(); capacity > 0 {
+		out += "/" + unparseDuration(capacity)
+	}
+	return out
+}
+
+// the summed estimates of the occurrences due on each day within [from, to)
+func estimateLoads(occs []*Occurrence, from, to time.Time) map[time.Time]time.Duration {
+	out := make(map[time.Time]time.Duration)
+	for _, occ := range occs {
+		if occ.Task.Est != nil && !occ.Due.Before(from) && occ.Due.Before(to) {
+			out[startOfDay(occ.Due)] += *occ.Task.Est
+		}
+	}
+	return out
+}
+
+// marks the estimates of the rendered tasks due on an overloaded day;
+// the load of a day is summed over all of the loaded lists.
+func markOverloaded(rtasks []*rTask) {
+	if dailyCapacity() == 0 {
+		return
+	}
+	var first, last *time.Time
+	for _, rtask := range rtasks {
+		if rtask.task == nil || rtask.task.Est == nil || rtask.task.Time.DueDate == nil {
+			continue
+		}
+		if due := rtask.task.Time.DueDate; first == nil || due.Before(*first) {
+			first = due
+		}
+		if due := rtask.task.Time.DueDate; last == nil || due.After(*last) {
+			last = due
+		}
+	}
+	if first == nil {
+		return
+	}
+	from, to := startOfDay(*first), startOfDay(*last).AddDate(0, 0, 1)
+	var occs []*Occurrence
+	for path, list := range Lists {
+		for _, t := range list.Tasks {
+			occs = append(occs, t.occurrences(ListName(path), from, to)...)
+		}
+	}
+	loads := estimateLoads(occs, from, to)
+	for _, rtask := range rtasks {
+		if rtask.task == nil || rtask.task.Est == nil || rtask.task.Time.DueDate == nil ||
+			!isOverloaded(loads[startOfDay(*rtask.task.Time.DueDate)]) {
+			continue
+		}
+		for _, tk := range rtask.tokens {
+			if tk.token != nil && tk.token.Type == TokenDuration && tk.token.Key == "est" {
+				tk.color = "print.color-overloaded"
+			}
+		}
+	}
+}
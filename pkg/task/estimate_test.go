@@ -0,0 +1,92 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimates(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	defer viper.Set("time.capacity", viper.GetString("time.capacity"))
+	viper.Set("time.capacity", "4h")
+
+	t.Run("parse", func(t *testing.T) {
+		task, err := ParseTask(nil, "write $est=1h30M $c=2025-01-10")
+		require.NoError(t, err)
+		require.NotNil(t, task.Est)
+		assert.Equal(90*time.Minute, *task.Est)
+		assert.Equal("write $est=1h30M", task.Norm())
+		for _, line := range []string{"write $est=0s", "write $est=-1h", "write $est=soon"} {
+			task, err := ParseTask(nil, line)
+			require.NoError(t, err)
+			assert.Nil(task.Est, line)
+		}
+	})
+
+	path, _ := parseFilepath("estimates")
+	Lists.Empty(path)
+	for _, line := range []string{
+		"report $id=r $est=1h $c=2025-01-10",
+		"draft $P=r $est=2h $due=2025-01-16T10 $c=2025-01-10",
+		"review $P=r $est=3h $due=2025-01-16T14 $c=2025-01-10",
+		"mail $est=1h $due=2025-01-17 $c=2025-01-10",
+	} {
+		require.NoError(t, AddTaskFromStr(line, path))
+	}
+
+	t.Run("rollup", func(t *testing.T) {
+		report, err := getTaskFromId(0, path)
+		require.NoError(t, err)
+		assert.Equal(6*time.Hour, report.TotalEstimate())
+		rtask := report.Render()
+		assert.Equal("Σ6h", rtask.tokens[len(rtask.tokens)-1].raw)
+
+		burnt, err := ParseTask(utils.MkPtr(9), "event $id=e $c=2025-01-10 $due=2025-01-12 $end=2025-01-14")
+		require.NoError(t, err)
+		burnt.Children = []*Task{{Est: utils.MkPtr(time.Hour)}}
+		rtask = burnt.Render() // the roll-up has no token of its own
+		assert.Equal("Σ1h", rtask.tokens[len(rtask.tokens)-1].raw)
+	})
+	t.Run("overloaded", func(t *testing.T) {
+		rtasks, _, err := RenderList(path)
+		require.NoError(t, err)
+		colors := make(map[string]string)
+		for _, rtask := range rtasks {
+			for _, tk := range rtask.tokens {
+				if tk.token != nil && tk.token.Key == "est" {
+					colors[rtask.task.NormRegular()] = tk.color
+				}
+			}
+		}
+		assert.Equal(map[string]string{
+			"report": "print.color-estimate",
+			"draft":  "print.color-overloaded",
+			"review": "print.color-overloaded",
+			"mail":   "print.color-estimate",
+		}, colors)
+
+		viper.Set("time.capacity", "")
+		rtasks, _, _ = RenderList(path)
+		for _, rtask := range rtasks {
+			for _, tk := range rtask.tokens {
+				assert.NotEqual("print.color-overloaded", tk.color)
+			}
+		}
+		viper.Set("time.capacity", "4h")
+	})
+	t.Run("agenda", func(t *testing.T) {
+		lines, err := RenderAgenda([]string{path}, rightNow, 3)
+		require.NoError(t, err)
+		text := strings.Join(lines, "\n")
+		assert.Contains(text, "Thu 16 Jan | est 5h/4h (overloaded)")
+		assert.Contains(text, "Fri 17 Jan | est 1h/4h\n")
+	})
+}
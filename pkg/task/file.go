@@ -141,6 +141,11 @@ func prepFileTaskFromPath(path string) (string, error) {
 	return path, nil
 }
 
+// the absolute path of a list under the todos directory
+func AbsListPath(path string) (string, error) {
+	return parseFilepath(path)
+}
+
 func CheckFileExistence(path string) error {
 	path, err := parseFilepath(path)
 	if err != nil {
@@ -2,6 +2,7 @@ package task
 
 import (
 	"dotxt/config"
+	"dotxt/pkg/terrors"
 	"dotxt/pkg/utils"
 	"fmt"
 	"maps"
@@ -111,11 +112,23 @@ func formatAbsoluteDatetime(dt *time.Time, relDt *time.Time) string {
 		return ""
 	}
 	if relDt == nil {
-		return dt.Format("2006-01-02T15-04")
+		return dt.In(LocalZone()).Format("2006-01-02T15-04")
 	}
 	return formatDuration(utils.MkPtr(dt.Sub(*relDt)))
 }
 
+// the $until and $times of a series as a suffix of its recurrence
+func formatRecurrenceBounds(t *Temporal) string {
+	var out string
+	if t.Until != nil {
+		out += ",until=" + formatAbsoluteDatetime(t.Until, &rightNow)
+	}
+	if t.Times != nil {
+		out += fmt.Sprintf(",times=%d", *t.Times)
+	}
+	return out
+}
+
 func formatPriorities(tasks []*rTask) {
 	if len(tasks) == 0 {
 		return
@@ -354,6 +367,10 @@ func formatProgress(p *Progress, countLen, doneCountLen int) []rToken {
 
 	percentage := 100 * p.Count / p.DoneCount
 	percentageColor := colorizePercentage(percentage)
+	unit := p.Unit
+	if p.rolledUp {
+		unit = "Σ" + unit
+	}
 	barText := func(width int) string {
 		pLen := width * p.Count / p.DoneCount
 		switch pLen {
@@ -371,7 +388,7 @@ func formatProgress(p *Progress, countLen, doneCountLen int) []rToken {
 		{raw: fmt.Sprintf("/%*d", doneCountLen, p.DoneCount), color: "print.progress.done-count"},
 		{raw: fmt.Sprintf("(%3d%%)", percentage), color: percentageColor},
 		{raw: fmt.Sprintf(" %s ", barText), color: percentageColor},
-		{raw: fmt.Sprintf("(%s)", p.Unit), color: "print.progress.unit"},
+		{raw: fmt.Sprintf("(%s)", unit), color: "print.progress.unit"},
 	}
 }
 
@@ -433,15 +450,23 @@ func formatID(tk Token) string {
 	return fmt.Sprintf("$%s%s=%s", idCollapse, tk.Key, *tk.Value.(*string))
 }
 
-func formatCategoryHeader(category string, info *rInfo) string {
+// the estimates of the tasks of the category are summed up after its name
+// followed by the totals of their progress by unit
+func formatCategoryHeader(category string, est time.Duration, totals []*Progress, info *rInfo) string {
+	if est > 0 {
+		category += " Σ" + unparseDuration(est)
+	}
 	var out strings.Builder
-	out.WriteString(strings.Repeat(" ", info.idLen+1+
+	out.WriteString(strings.Repeat(" ", max(info.idLen+1+
 		info.countLen+1+info.doneCountLen+utils.RuneCount("(100%) ")+
 		viper.GetInt("print.progress.bartext-len")+1+
-		-utils.RuneCount(category)-1,
+		-utils.RuneCount(category)-1, 0),
 	))
 	out.WriteString(fmt.Sprintf("%s ", category))
-	out.WriteString(strings.Repeat("—", info.maxLen-out.Len()))
+	for _, total := range totals {
+		out.WriteString(fmt.Sprintf("%d/%d %s (%d%%) ", total.Count, total.DoneCount, total.Unit, 100*total.Count/total.DoneCount))
+	}
+	out.WriteString(strings.Repeat("—", max(info.maxLen-utils.RuneCount(out.String()), 0)))
 	out.WriteRune('\n')
 	return colorize("print.progress.header", out.String())
 }
@@ -457,6 +482,11 @@ func (t *Task) Render() *rTask {
 		out.tokens = append(out.tokens, &rToken{token: tk})
 		out.countLen = utils.RuneCount(strconv.Itoa(t.Prog.Count))
 		out.doneCountLen = utils.RuneCount(strconv.Itoa(t.Prog.DoneCount))
+	} else if agg := t.AggregateProgress(); agg != nil { // takes the slot of the progress bar
+		raw, _ := unparseProgress(*agg)
+		out.tokens = append(out.tokens, &rToken{token: &Token{Type: TokenProgress, Key: "p", Value: agg, raw: &raw}})
+		out.countLen = utils.RuneCount(strconv.Itoa(agg.Count))
+		out.doneCountLen = utils.RuneCount(strconv.Itoa(agg.DoneCount))
 	}
 	if t.Priority != nil {
 		tk, _ := t.Tokens.Find(TkPriorityPrefix)
@@ -607,17 +637,15 @@ func (t *Task) Render() *rTask {
 					return
 				}
 				color := "print.color-date-" + tk.Key
-				if (tk.Key == "due" && IsDateUrgent(t.Time.DueDate)) ||
-					(tk.Key == "end" && IsDateUrgent(t.Time.EndDate)) ||
-					(tk.Key == "dead" && IsDateUrgent(t.Time.Deadline)) {
-					color = "print.color-urgent"
+				level := t.fieldUrgency(tk.Key)
+				if level != UrgencyNone {
+					color = level.color()
 				}
-				if t.Time.DueDate != nil &&
+				if level != UrgencyOverdue && t.Time.DueDate != nil &&
 					t.Time.DueDate.Sub(rightNow) <= 0 {
 					if tk.Key == "due" {
 						color = "print.color-burnt"
-					} else if tk.Key == "dead" &&
-						IsDateUrgent(t.Time.Deadline) {
+					} else if tk.Key == "dead" && level != UrgencyNone {
 						color = "print.color-imminent-deadline"
 					} else if tk.Key == "end" &&
 						t.Time.EndDate != nil &&
@@ -631,6 +659,16 @@ func (t *Task) Render() *rTask {
 					color: color,
 				})
 			}
+			if tk.Key == "t" {
+				if !t.IsDeferred() { // a passed threshold is of no concern
+					return
+				}
+				out.tokens = append(out.tokens, &rToken{
+					token: tk,
+					raw:   fmt.Sprintf("$t=%s", formatAbsoluteDatetime(t.Time.Threshold, &rightNow)),
+					color: "print.color-hidden",
+				})
+			}
 			if strings.HasPrefix(tk.Key, "r") {
 				if reminderCount >= len(t.Time.Reminders) {
 					addAsRegular(tk)
@@ -658,9 +696,24 @@ func (t *Task) Render() *rTask {
 				})
 			}
 		case TokenDuration:
+			if tk.Key == "est" {
+				out.tokens = append(out.tokens, &rToken{
+					token: tk, raw: tk.String(), color: "print.color-estimate",
+				})
+				return
+			}
+			out.tokens = append(out.tokens, &rToken{
+				token: tk,
+				raw:   fmt.Sprintf("$every=%s", formatDuration(t.Time.Every)) + formatRecurrenceBounds(t.Time),
+				color: "print.color-every",
+			})
+		case TokenRecurrence:
+			if tk.Key == "times" { // shown along with the recurrence
+				return
+			}
 			out.tokens = append(out.tokens, &rToken{
 				token: tk,
-				raw:   fmt.Sprintf("$every=%s", formatDuration(t.Time.Every)),
+				raw:   tk.String() + formatRecurrenceBounds(t.Time),
 				color: "print.color-every",
 			})
 		case TokenFormat:
@@ -676,12 +729,23 @@ func (t *Task) Render() *rTask {
 			addAsRegular(tk)
 		}
 	})
+	if len(t.Children) > 0 { // the estimates of the subtasks are rolled up
+		var own time.Duration
+		if t.Est != nil {
+			own = *t.Est
+		}
+		if total := t.TotalEstimate(); total > own {
+			out.tokens = append(out.tokens, &rToken{raw: "Σ" + unparseDuration(total), color: "print.color-estimate"})
+		}
+	}
 	out.maxLen = utils.RuneCount(out.stringify(false, -1))
 	out.idLen = utils.RuneCount(strconv.Itoa(*t.ID))
 	if dominantColor != "" || defaultColor != "" {
 		for _, rtk := range out.tokens {
-			rtk.dominantColor = dominantColor
-			if rtk.token.Type == TokenText && defaultColor != "" {
+			if rtk.color != "print.color-overdue" { // overdue dates stand out of burnt tasks
+				rtk.dominantColor = dominantColor
+			}
+			if rtk.token != nil && rtk.token.Type == TokenText && defaultColor != "" {
 				rtk.color = defaultColor
 			}
 		}
@@ -689,6 +753,52 @@ func (t *Task) Render() *rTask {
 	return &out
 }
 
+var (
+	ShowDeferred bool // whether tasks hidden by their threshold are rendered too
+	ShowLater    bool // whether tasks beyond the horizon are rendered too
+)
+
+// the moment after which time-bound tasks are summarised as later; nil if unset.
+// it is either 'week' for the end of the current week, optionally followed by
+// ':<duration>' to push it further, or a duration from now.
+func ParseHorizon(value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	if rest, ok := strings.CutPrefix(value, "week"); ok {
+		eow := startOfWeek(rightNow).AddDate(0, 0, 7)
+		if rest == "" {
+			return &eow, nil
+		}
+		if rest, ok = strings.CutPrefix(rest, ":"); !ok {
+			return nil, fmt.Errorf("%w: horizon '%s'", terrors.ErrParse, value)
+		}
+		dur, err := parseDuration(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: horizon: %w", terrors.ErrParse, err)
+		}
+		return utils.MkPtr(eow.Add(*dur)), nil
+	}
+	dur, err := parseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: horizon: %w", terrors.ErrParse, err)
+	}
+	return utils.MkPtr(rightNow.Add(*dur)), nil
+}
+
+// whether the task is due beyond the horizon with no reminder before it
+func (t *Task) isLater(horizon time.Time) bool {
+	if t.Time.DueDate == nil || !t.Time.DueDate.After(horizon) {
+		return false
+	}
+	for _, r := range t.Time.Reminders {
+		if !r.After(horizon) {
+			return false
+		}
+	}
+	return true
+}
+
 func RenderList(path string) ([]*rTask, *rInfo, error) {
 	path, err := prepFileTaskFromPath(path)
 	if err != nil {
@@ -707,6 +817,14 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 	parentToChildrenDepth := map[*Task]int{nil: 0}
 	parentToChildrenFocus := make(map[*Task]bool)
 	taskToRTask := make(map[*Task]*rTask)
+	clocks, err := runningClocks(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	horizon, err := ParseHorizon(viper.GetString("print.horizon"))
+	if err != nil {
+		return nil, nil, err
+	}
 	Lists.Sort(path)
 
 	var parentStack []*Task
@@ -737,6 +855,12 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 		}
 
 		rtask := task.Render()
+		if start, ok := clocks[taskKey(task)]; ok {
+			rtask.tokens = append(rtask.tokens, &rToken{
+				raw: "⏱" + formatTracked(rightNow.Sub(start)), color: "print.color-running-event",
+			})
+			rtask.maxLen = utils.RuneCount(rtask.stringify(false, -1))
+		}
 		taskToRTask[task] = rtask
 		parentToChildren[task.Parent] = append(parentToChildren[task.Parent], task)
 		if rtask.focused {
@@ -766,7 +890,20 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 			decor: true,
 		})
 	}
+	flushDeferred := func(count, depth int) {
+		out = append(out, &rTask{
+			tokens: []*rToken{
+				{raw: "...", color: "print.color-hidden"},
+				{raw: "-" + strconv.Itoa(count), color: "print.color-hidden"},
+				{raw: "$t", color: "print.color-hidden"},
+				{raw: "...", color: "print.color-hidden"},
+			},
+			depth: depth,
+			decor: true,
+		})
+	}
 
+	var laterCount int
 	var render func(*Task)
 	render = func(node *Task) {
 		siblings, ok := parentToChildren[node]
@@ -775,7 +912,7 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 		}
 		siblingDepth := parentToChildrenDepth[node]
 		var shf bool = parentToChildrenFocus[node] // siblings have focus
-		var hiddenCount int
+		var hiddenCount, deferredCount int
 		for _, task := range siblings {
 			rtask := taskToRTask[task]
 			if shf && !taskHasFocus(task) && !taskHasDerivedFocus(rtask) {
@@ -791,6 +928,14 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 			if task.IsParentCollapsed() {
 				continue
 			}
+			if !ShowDeferred && task.IsDeferred() && !taskHasFocus(task) && !taskHasDerivedFocus(rtask) {
+				deferredCount += 1 + len(task.Children)
+				continue
+			}
+			if !ShowLater && horizon != nil && task.isLater(*horizon) && !taskHasFocus(task) && !taskHasDerivedFocus(rtask) {
+				laterCount += 1 + len(task.Children)
+				continue
+			}
 			out = append(out, rtask)
 			if rtask.task != nil {
 				if rtask.task.EID != nil {
@@ -809,8 +954,23 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 		if hiddenCount > 0 {
 			flushEllipsis(hiddenCount, siblingDepth)
 		}
+		if deferredCount > 0 {
+			flushDeferred(deferredCount, siblingDepth)
+		}
 	}
 	render(nil)
+	if laterCount > 0 {
+		out = append(out, &rTask{
+			tokens: []*rToken{
+				{raw: "...", color: "print.color-hidden"},
+				{raw: "-" + strconv.Itoa(laterCount), color: "print.color-hidden"},
+				{raw: "later", color: "print.color-hidden"},
+				{raw: "...", color: "print.color-hidden"},
+			},
+			decor: true,
+		})
+	}
+	markOverloaded(out)
 	idColors := colorizeIds(idList)
 	for _, rtask := range out {
 		for _, tk := range rtask.tokens {
@@ -828,12 +988,19 @@ func RenderList(path string) ([]*rTask, *rInfo, error) {
 	return out, &listInfo, nil
 }
 
-func PrintLists(paths []string, maxLen, minLen int) error {
+// A formatted line of a list as printed by PrintLists
+type Line struct {
+	ID   *int   // nil for decorations; e.g. headers and ellipses
+	Raw  string // the raw text of the task
+	Text string // might span multiple lines if folded
+}
+
+func formatLists(paths []string, maxLen, minLen int) (map[string][]Line, error) {
 	var err error
 	for ndx := range paths {
 		paths[ndx], err = prepFileTaskFromPath(paths[ndx])
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	rtasks := make(map[string][]*rTask)
@@ -842,7 +1009,7 @@ func PrintLists(paths []string, maxLen, minLen int) error {
 		var listInfo *rInfo
 		rtasks[path], listInfo, err = RenderList(path)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		sessionInfo.set(listInfo)
 	}
@@ -853,8 +1020,12 @@ func PrintLists(paths []string, maxLen, minLen int) error {
 			rtask.rInfo.set(&sessionInfo)
 		}
 	}
-	var out strings.Builder
+	out := make(map[string][]Line)
 	for _, path := range paths {
+		var lines []Line
+		writeDecor := func(text string) {
+			lines = append(lines, Line{Text: strings.TrimSuffix(text, "\n")})
+		}
 		emptyCatThere := false
 		categories := make(map[string]bool)
 		for _, rtask := range rtasks[path] {
@@ -868,10 +1039,18 @@ func PrintLists(paths []string, maxLen, minLen int) error {
 			}
 		}
 		useCatHeader := !((len(categories) == 1 && emptyCatThere) || len(categories) == 0)
+		catEstimates := make(map[string]time.Duration)
+		catTotals := make(map[string][]*Progress)
+		for _, rtask := range rtasks[path] {
+			if rtask.task != nil && rtask.task.Prog != nil && rtask.task.Root() == rtask.task {
+				catEstimates[rtask.task.Prog.Category] += rtask.task.TotalEstimate()
+				catTotals[rtask.task.Prog.Category] = addProgressByUnit(catTotals[rtask.task.Prog.Category], rtask.task.Prog)
+			}
+		}
 		var lastCat string
 		firstNonCat := true
 
-		out.WriteString(formatListHeader(path, sessionInfo.maxLen))
+		writeDecor(formatListHeader(path, sessionInfo.maxLen))
 		for _, rtask := range rtasks[path] {
 			if useCatHeader && rtask.task != nil && rtask.task.Prog != nil && rtask.task.Prog.Category != lastCat {
 				if root := rtask.task.Root(); root == rtask.task { // not a nested progress
@@ -879,18 +1058,38 @@ func PrintLists(paths []string, maxLen, minLen int) error {
 					if cat == "" {
 						cat = "*"
 					}
-					out.WriteString(formatCategoryHeader(cat, &sessionInfo))
+					writeDecor(formatCategoryHeader(cat, catEstimates[rtask.task.Prog.Category], catTotals[rtask.task.Prog.Category], &sessionInfo))
 					lastCat = rtask.task.Prog.Category
 				}
 			}
 			if useCatHeader && rtask.task != nil && rtask.task.Prog == nil && firstNonCat {
 				if root := rtask.task.Root(); root == rtask.task { // not a nested progress
 					firstNonCat = false
-					out.WriteString(formatCategoryHeader("", &sessionInfo))
+					writeDecor(formatCategoryHeader("", 0, nil, &sessionInfo))
 				}
 			}
 
-			out.WriteString(rtask.stringify(true, sessionInfo.maxLen))
+			line := Line{Text: rtask.stringify(true, sessionInfo.maxLen)}
+			if rtask.task != nil && !rtask.decor {
+				line.ID = rtask.task.ID
+				line.Raw = rtask.task.Raw()
+			}
+			lines = append(lines, line)
+		}
+		out[path] = lines
+	}
+	return out, nil
+}
+
+func PrintLists(paths []string, maxLen, minLen int) error {
+	lines, err := formatLists(paths, maxLen, minLen)
+	if err != nil {
+		return err
+	}
+	var out strings.Builder
+	for _, path := range paths {
+		for _, line := range lines[path] {
+			out.WriteString(line.Text)
 			out.WriteRune('\n')
 		}
 		out.WriteRune('\n')
@@ -899,6 +1098,16 @@ func PrintLists(paths []string, maxLen, minLen int) error {
 	return nil
 }
 
+// the formatted lines of a single list
+func RenderLines(path string, maxLen, minLen int) ([]Line, error) {
+	paths := []string{path}
+	lines, err := formatLists(paths, maxLen, minLen)
+	if err != nil {
+		return nil, err
+	}
+	return lines[paths[0]], nil
+}
+
 // single task
 func PrintTask(id int, path string, maxWidth int) error {
 	path, err := prepFileTaskFromPath(path)
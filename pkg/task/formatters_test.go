@@ -315,10 +315,15 @@ func TestColorIds(t *testing.T) {
 func TestFormatCategoryHeader(t *testing.T) {
 	assert := assert.New(t)
 	l := rInfo{idLen: 2, countLen: 2, doneCountLen: 2, maxLen: 30}
-	out := formatCategoryHeader("some", &l)
+	out := formatCategoryHeader("some", 0, nil, &l)
 	assert.Equal("                     some ————\n", out)
-	out = formatCategoryHeader("", &l)
+	out = formatCategoryHeader("", 0, nil, &l)
 	assert.Equal("                          ————\n", out)
+	out = formatCategoryHeader("some", 2*time.Hour, nil, &l)
+	assert.Equal("                 some Σ2h ————\n", out)
+	l.maxLen = 50
+	out = formatCategoryHeader("some", 0, []*Progress{{Unit: "page", Count: 12, DoneCount: 40}}, &l)
+	assert.Equal("                     some 12/40 page (30%) ———————\n", out)
 }
 
 func TestRender(t *testing.T) {
@@ -425,9 +430,12 @@ func TestRender(t *testing.T) {
 		task.updateDate("due", &dt)
 		rtask := task.Render()
 		assert.Equal("$due=-4d", rtask.tokens[5].raw)
-		assert.Equal("print.color-burnt", rtask.tokens[5].color)
-		for _, tk := range rtask.tokens {
-			assert.Equal("print.color-burnt", tk.dominantColor)
+		assert.Equal("print.color-overdue", rtask.tokens[5].color)
+		assert.Empty(rtask.tokens[5].dominantColor)
+		for ndx, tk := range rtask.tokens {
+			if ndx != 5 {
+				assert.Equal("print.color-burnt", tk.dominantColor)
+			}
 		}
 	})
 	t.Run("after due before end", func(t *testing.T) {
@@ -910,18 +918,18 @@ func TestPrintLists(t *testing.T) {
 
 		out := capture(60, 50)
 		tc := `> printLists | ———————————————————————————————————
-                         a ———————————————————————
+                         a 5/20 unit (25%) ———————
 10  5/ 20( 25%) =>         (unit) a $id=a
    13 70/100( 70%) ======>    (unit) d $P=a
    12 5/100(  5%)            (unit) c $P=a
    14 80/150( 53%) ====>      (unit) e $P=a
-                         c ———————————————————————
+                         c 3/10 unit (30%) ———————
 05  3/ 10( 30%) ==>        (unit) 5 $id=5
    06 4/8( 50%) ====>      (unit) 6 $P=5 $id=6
       09 9 $P=6
    07 4/10( 40%) ===>       (unit) 7 $P=5 $id=7
       08 5/15( 33%) ==>        (unit) 8 $P=7
-                         * ———————————————————————
+                         * 2/5 unit (40%) ————————
 00  2/  5( 40%) ===>       (unit) 0 $id=0
    03 4/8( 50%) ====>      (unit) 3 $P=0
    02 3/7( 42%) ===>       (unit) 2 $P=0
@@ -982,6 +990,37 @@ func TestPrintLists(t *testing.T) {
 	})
 }
 
+func TestRenderLines(t *testing.T) {
+	assert := assert.New(t)
+	path, _ := parseFilepath("renderLines")
+	Lists.Empty(path)
+	AddTaskFromStr("0 $id=1 $p=unit/1/2/cat", path)
+	AddTaskFromStr("1 $P=1", path)
+	AddTaskFromStr("2", path)
+
+	lines, err := RenderLines(path, 50, 50)
+	require.NoError(t, err)
+	var texts []string
+	for _, line := range lines {
+		texts = append(texts, line.Text)
+	}
+	assert.Equal([]string{
+		"> renderLines | ——————————————————————————————————",
+		"                   cat 1/2 unit (50%) ————————————",
+		"0 1/2( 50%) ====>      (unit) 0 $id=1",
+		"  1 1 $P=1",
+		"                       ———————————————————————————",
+		"2 2",
+	}, texts)
+	assert.Nil(lines[0].ID)
+	assert.Nil(lines[1].ID)
+	if assert.NotNil(lines[3].ID) {
+		assert.Equal(1, *lines[3].ID)
+	}
+	assert.True(strings.HasPrefix(lines[3].Raw, "1 $P=1 $c="))
+	assert.Nil(lines[4].ID)
+}
+
 func TestPrintTask(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1048,3 +1087,96 @@ func TestOutputTask(t *testing.T) {
 	out = capture(0)
 	assert.Equal(fmt.Sprintf("(A) +prj #tag @at $due=1d $dead=1w $r=-2h $id=3 $P=2 $p=unit/2/15/cat text $r=-3d $every=1m $c=%s\n", rn), out)
 }
+
+func TestRenderDeferred(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	defer func() { ShowDeferred = false }()
+
+	path, _ := parseFilepath("deferred")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("now $c=2025-01-10T09", path))
+	require.NoError(t, AddTaskFromStr("prep $c=2025-01-10T09 $due=2025-01-20T09 $t=due:-2d", path))
+	require.NoError(t, AddTaskFromStr("past $c=2025-01-10T09 $t=2d", path))
+	require.NoError(t, AddTaskFromStr("$id=a later $c=2025-01-10T09 $t=1w", path))
+	require.NoError(t, AddTaskFromStr("child $P=a $c=2025-01-10T09", path))
+	require.NoError(t, AddTaskFromStr("focused $c=2025-01-10T09 $t=1w $focus", path))
+	require.NoError(t, cleanupRelations(path))
+	assert.Equal(time.Date(2025, 1, 18, 9, 0, 0, 0, time.Local), *Lists[path].Tasks[1].Time.Threshold)
+	assert.False(Lists[path].Tasks[2].IsDeferred())
+
+	texts := func() []string {
+		rtasks, _, err := RenderList(path)
+		require.NoError(t, err)
+		var out []string
+		for _, rt := range rtasks {
+			out = append(out, strings.TrimSpace(rt.stringify(false, 80)))
+		}
+		return out
+	}
+	// focus takes precedence over the threshold
+	assert.Equal([]string{"... -1 ...", "5 focused $t=2d $focus", "... -4 ..."}, texts())
+	focused, _ := getTaskFromId(5, path)
+	focused.unfocus()
+	assert.Equal([]string{"0 now", "2 past", "... -4 $t ..."}, texts())
+	ShowDeferred = true
+	assert.Contains(texts(), "1 prep $due=5d $t=3d")
+	assert.Len(texts(), 6)
+}
+
+func TestRenderLater(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local) // wednesday
+	defer viper.Set("time.week-start", viper.GetString("time.week-start"))
+	viper.Set("time.week-start", "sat")
+	defer viper.Set("print.horizon", viper.GetString("print.horizon"))
+	defer func() { ShowLater = false }()
+
+	t.Run("parse", func(t *testing.T) {
+		for value, expected := range map[string]time.Time{
+			"week":     time.Date(2025, 1, 18, 0, 0, 0, 0, time.Local),
+			"week:2d":  time.Date(2025, 1, 20, 0, 0, 0, 0, time.Local),
+			"week:-1d": time.Date(2025, 1, 17, 0, 0, 0, 0, time.Local),
+			"3d":       time.Date(2025, 1, 18, 9, 0, 0, 0, time.Local),
+		} {
+			horizon, err := ParseHorizon(value)
+			require.NoError(t, err, value)
+			assert.Equal(expected, *horizon, value)
+		}
+		horizon, err := ParseHorizon("")
+		assert.NoError(err)
+		assert.Nil(horizon)
+		for _, value := range []string{"weekly", "week:", "week2d", "soon"} {
+			_, err := ParseHorizon(value)
+			assert.Error(err, value)
+		}
+	})
+
+	path, _ := parseFilepath("later")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("soon $c=2025-01-10T09 $due=2025-01-19T09", path))
+	require.NoError(t, AddTaskFromStr("far $c=2025-01-10T09 $due=2025-01-25T09", path))
+	require.NoError(t, AddTaskFromStr("reminded $c=2025-01-10T09 $due=2025-01-25T09 $r=2025-01-19T09", path))
+	require.NoError(t, AddTaskFromStr("$id=a farther $c=2025-01-10T09 $due=2025-02-25T09", path))
+	require.NoError(t, AddTaskFromStr("child $P=a $c=2025-01-10T09", path))
+	require.NoError(t, AddTaskFromStr("timeless $c=2025-01-10T09", path))
+	require.NoError(t, cleanupRelations(path))
+	texts := func() []string {
+		rtasks, _, err := RenderList(path)
+		require.NoError(t, err)
+		var out []string
+		for _, rt := range rtasks {
+			out = append(out, strings.TrimSpace(rt.stringify(false, 80)))
+		}
+		return out
+	}
+
+	viper.Set("print.horizon", "")
+	assert.Len(texts(), 6)
+	viper.Set("print.horizon", "week:2d")
+	assert.Equal([]string{"0 soon $due=4d", "2 reminded $due=1w3d $r=4d", "5 timeless", "... -3 later ..."}, texts())
+	ShowLater = true
+	assert.Len(texts(), 6)
+}
@@ -0,0 +1,174 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// a span of free time within the working hours
+type Slot struct {
+	From, To time.Time
+}
+
+func (s Slot) duration() time.Duration {
+	return s.To.Sub(s.From)
+}
+
+// the free slots of at least minLen within the working hours of the working days
+// in the given number of days from the start of from; the time of the events
+// of the given lists and whatever has passed are taken out.
+func FreeSlots(paths []string, from time.Time, days int, minLen time.Duration) ([]Slot, error) {
+	from = startOfDay(from)
+	to := from.AddDate(0, 0, days)
+	occs, err := Occurrences(paths, from, to)
+	if err != nil {
+		return nil, err
+	}
+	evs := events(occs)
+	workdays := workDays()
+	start, end := workHours()
+	var out []Slot
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !isWorkDay(day, workdays) {
+			continue
+		}
+		free := []Slot{{
+			From: day.Add(time.Duration(start) * time.Minute),
+			To:   day.Add(time.Duration(end) * time.Minute),
+		}}
+		if free[0].From.Before(rightNow) {
+			free[0].From = rightNow
+		}
+		for _, ev := range evs {
+			free = subtractSpan(free, ev.Due, *ev.End)
+		}
+		for _, slot := range free {
+			if slot.duration() >= minLen && slot.duration() > 0 {
+				out = append(out, slot)
+			}
+		}
+	}
+	return out, nil
+}
+
+// the slots without the span [from, to)
+func subtractSpan(slots []Slot, from, to time.Time) []Slot {
+	var out []Slot
+	for _, slot := range slots {
+		if !from.Before(slot.To) || !to.After(slot.From) {
+			out = append(out, slot)
+			continue
+		}
+		if from.After(slot.From) {
+			out = append(out, Slot{slot.From, from})
+		}
+		if to.Before(slot.To) {
+			out = append(out, Slot{to, slot.To})
+		}
+	}
+	return out
+}
+
+// a due date proposed for a task without one
+type Proposal struct {
+	Task *Task
+	List string // name of the list
+	Due  time.Time
+}
+
+// places the tasks of the given lists which have an estimate but no due date
+// into the earliest slots they fit in; the most urgent and prioritized first.
+// tasks that fit nowhere are left out.
+func ProposeSchedule(paths []string, slots []Slot) ([]*Proposal, error) {
+	var out []*Proposal
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range Lists[path].Tasks {
+			if t.Est != nil && t.Time.DueDate == nil && !t.IsDeferred() {
+				out = append(out, &Proposal{Task: t, List: ListName(path)})
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(l, r *Proposal) int {
+		for _, f := range []func(l, r *Task) int{sortUrgency, sortPriority} {
+			if v := f(l.Task, r.Task); v != 2 {
+				return v
+			}
+		}
+		return 0
+	})
+	slots = slices.Clone(slots)
+	var placed []*Proposal
+	for _, p := range out {
+		for ndx, slot := range slots {
+			if slot.duration() >= *p.Task.Est {
+				p.Due = slot.From
+				slots[ndx].From = slot.From.Add(*p.Task.Est)
+				placed = append(placed, p)
+				break
+			}
+		}
+	}
+	return placed, nil
+}
+
+func formatSlot(slot Slot) string {
+	from, to := slot.From.In(LocalZone()), slot.To.In(LocalZone())
+	return fmt.Sprintf("  %s-%s  ", from.Format("15:04"), to.Format("15:04"))
+}
+
+// the free slots grouped by day followed by the proposals if there are any
+func RenderFreeSlots(slots []Slot, proposals []*Proposal, from time.Time, days int) []string {
+	from = startOfDay(from)
+	header := fmt.Sprintf("> free | %s — %s ", from.Format("2006-01-02"), from.AddDate(0, 0, days-1).Format("2006-01-02"))
+	out := []string{colorize("print.color-header", header+strings.Repeat("—", max(80-utils.RuneCount(header), 0)))}
+	var lastDay time.Time
+	for _, slot := range slots {
+		if day := startOfDay(slot.From); !day.Equal(lastDay) {
+			out = append(out, colorize("print.color-header", day.Format("Mon 02 Jan")))
+			lastDay = day
+		}
+		out = append(out, colorize("print.color-index", formatSlot(slot))+
+			colorize("print.color-running-event", unparseDuration(slot.duration())))
+	}
+	if len(slots) == 0 {
+		out = append(out, colorize("print.color-hidden", "no free time"))
+	}
+	if len(proposals) > 0 {
+		out = append(out, colorize("print.color-header", "proposals"))
+		idLen := 1
+		for _, p := range proposals {
+			idLen = max(idLen, len(strconv.Itoa(*p.Task.ID)))
+		}
+		for _, p := range proposals {
+			out = append(out, colorize("print.color-index", fmt.Sprintf("  %s:%0*d ", p.List, idLen, *p.Task.ID))+
+				colorize("print.color-default", occurrenceText(&Occurrence{Task: p.Task})+" ")+
+				colorize("print.color-estimate", "$est="+unparseDuration(*p.Task.Est)+" ")+
+				colorize("print.color-date-due", "$due="+unparseAbsoluteDatetime(p.Due)))
+		}
+	}
+	return out
+}
+
+func PrintFreeSlots(paths []string, from time.Time, days int, minLen time.Duration, propose bool) error {
+	slots, err := FreeSlots(paths, from, days, minLen)
+	if err != nil {
+		return err
+	}
+	var proposals []*Proposal
+	if propose {
+		proposals, err = ProposeSchedule(paths, slots)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println(strings.Join(RenderFreeSlots(slots, proposals, from, days), "\n"))
+	return nil
+}
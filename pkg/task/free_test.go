@@ -0,0 +1,61 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeSlots(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 16, 10, 30, 0, 0, time.Local) // a thursday
+	for _, key := range []string{"time.work-days", "time.work-hours"} {
+		defer viper.Set(key, viper.GetString(key))
+	}
+	viper.Set("time.work-days", "mon,tue,wed,thu,fri")
+	viper.Set("time.work-hours", "09:00-17:00")
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 1, day, hour, minute, 0, 0, time.Local)
+	}
+
+	path, _ := parseFilepath("free")
+	Lists.Empty(path)
+	for _, line := range []string{
+		"standup $c=2025-01-10 $due=2025-01-16T09 $end=2025-01-16T09-15 $every=1d",
+		"review $c=2025-01-10 $due=2025-01-16T13 $end=2025-01-16T14-30",
+		"workshop $c=2025-01-10 $due=2025-01-17T12 $end=2025-01-17T16-45",
+		"report $c=2025-01-10 $due=2025-01-16T15",
+		"write $c=2025-01-10 $est=3h",
+		"(A) plan $c=2025-01-10 $est=1h",
+		"tidy $c=2025-01-10 $est=20M",
+		"huge $c=2025-01-10 $est=1d",
+	} {
+		require.NoError(t, AddTaskFromStr(line, path))
+	}
+
+	slots, err := FreeSlots([]string{path}, rightNow, 5, 30*time.Minute)
+	require.NoError(t, err)
+	assert.Equal([]Slot{
+		{at(16, 10, 30), at(16, 13, 0)},
+		{at(16, 14, 30), at(16, 17, 0)},
+		{at(17, 9, 15), at(17, 12, 0)},
+		// the weekend is skipped
+		{at(20, 9, 15), at(20, 17, 0)},
+	}, slots)
+
+	proposals, err := ProposeSchedule([]string{path}, slots)
+	require.NoError(t, err)
+	dues := make(map[string]time.Time)
+	for _, p := range proposals {
+		dues[p.Task.NormRegular()] = p.Due
+	}
+	assert.Equal(map[string]time.Time{
+		"plan":  at(16, 10, 30),
+		"tidy":  at(16, 11, 30),
+		"write": at(20, 9, 15), // friday morning is too short
+	}, dues)
+}
@@ -0,0 +1,153 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// whether the task has a priority at or above 'matrix.importance';
+// e.g. with 'B', '(A)' and '(B)' are important but '(C)' and '[A]' are not
+func (t *Task) IsImportant() bool {
+	if t.Priority == nil {
+		return false
+	}
+	if tk, _ := t.Tokens.Find(TkByTypeKey(TokenPriority, "anti-priority")); tk != nil {
+		return false
+	}
+	prio := utils.RuneSlice(*t.Priority, 1, utils.RuneCount(*t.Priority)-1)
+	return prio <= viper.GetString("matrix.importance")
+}
+
+// a task placed within the matrix
+type matrixItem struct {
+	task *Task
+	path string
+}
+
+var matrixTitles = [4]string{
+	"do: urgent & important", "schedule: important",
+	"delegate: urgent", "drop: neither",
+}
+
+// the tasks of the given lists by quadrant in the order of matrixTitles;
+// tasks hidden by their threshold are left out.
+func matrixQuadrants(paths []string) ([4][]*matrixItem, error) {
+	var out [4][]*matrixItem
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return out, err
+		}
+		for _, t := range Lists[path].Tasks {
+			if t.IsDeferred() {
+				continue
+			}
+			ndx := 3
+			switch urgent, important := t.IsUrgent(), t.IsImportant(); {
+			case urgent && important:
+				ndx = 0
+			case important:
+				ndx = 1
+			case urgent:
+				ndx = 2
+			}
+			out[ndx] = append(out[ndx], &matrixItem{t, path})
+		}
+	}
+	return out, nil
+}
+
+// the lines of an item folded into width runes as pairs of colored and plain text
+func (m *matrixItem) lines(width int) ([]string, []string) {
+	prefix := ListName(m.path) + ":"
+	rt := m.task.Render()
+	rt.depth = 0 // parents may sit in other quadrants
+	n := utils.RuneCount(prefix)
+	colored := strings.Split(rt.stringify(true, max(width-n, 8)), "\n")
+	plain := strings.Split(rt.stringify(false, max(width-n, 8)), "\n")
+	for ndx := range colored {
+		if ndx == 0 {
+			colored[ndx] = colorize("print.color-index", prefix) + colored[ndx]
+			plain[ndx] = prefix + plain[ndx]
+		} else {
+			colored[ndx] = strings.Repeat(" ", n) + colored[ndx]
+			plain[ndx] = strings.Repeat(" ", n) + plain[ndx]
+		}
+	}
+	return colored, plain
+}
+
+// a border of the matrix; titles are written into it
+func matrixBorder(left, middle, right string, titles []string, width int) string {
+	var out strings.Builder
+	out.WriteString(colorize("print.color-index", left))
+	for ndx, title := range titles {
+		if ndx > 0 {
+			out.WriteString(colorize("print.color-index", middle))
+		}
+		if title == "" {
+			out.WriteString(colorize("print.color-index", strings.Repeat("─", width)))
+			continue
+		}
+		title = " " + title + " "
+		out.WriteString(colorize("print.color-index", "─"))
+		out.WriteString(colorize("print.color-header", title))
+		out.WriteString(colorize("print.color-index", strings.Repeat("─", max(width-1-utils.RuneCount(title), 0))))
+	}
+	out.WriteString(colorize("print.color-index", right))
+	return out.String()
+}
+
+// the eisenhower matrix of the given lists as lines; the quadrants are
+// boxed two by two and the whole of it spans width runes.
+func RenderMatrix(paths []string, width int) ([]string, error) {
+	quadrants, err := matrixQuadrants(paths)
+	if err != nil {
+		return nil, err
+	}
+	cellWidth := (width - 3) / 2
+	header := "> matrix "
+	out := []string{colorize("print.color-header", header+strings.Repeat("—", max(cellWidth*2+3-utils.RuneCount(header), 0)))}
+	for row := range 2 {
+		titles := matrixTitles[row*2 : row*2+2]
+		if row == 0 {
+			out = append(out, matrixBorder("┌", "┬", "┐", titles, cellWidth))
+		} else {
+			out = append(out, matrixBorder("├", "┼", "┤", titles, cellWidth))
+		}
+		var colored, plain [2][]string
+		for col := range 2 {
+			for _, item := range quadrants[row*2+col] {
+				c, p := item.lines(cellWidth - 1)
+				colored[col], plain[col] = append(colored[col], c...), append(plain[col], p...)
+			}
+		}
+		for line := range max(len(colored[0]), len(colored[1]), 1) {
+			var b strings.Builder
+			for col := range 2 {
+				b.WriteString(colorize("print.color-index", "│"))
+				text, n := " ", 1
+				if line < len(colored[col]) {
+					text, n = " "+colored[col][line], 1+utils.RuneCount(plain[col][line])
+				}
+				b.WriteString(text + strings.Repeat(" ", max(cellWidth-n, 0)))
+			}
+			b.WriteString(colorize("print.color-index", "│"))
+			out = append(out, b.String())
+		}
+	}
+	out = append(out, matrixBorder("└", "┴", "┘", []string{"", ""}, cellWidth))
+	return out, nil
+}
+
+func PrintMatrix(paths []string, width int) error {
+	lines, err := RenderMatrix(paths, width)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
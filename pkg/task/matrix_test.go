@@ -0,0 +1,57 @@
+package task
+
+import (
+	"dotxt/config"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	defer viper.Set("matrix.importance", viper.GetString("matrix.importance"))
+	viper.Set("matrix.importance", "B")
+	prevColor := config.Color
+	defer func() { config.Color = prevColor }()
+	config.Color = false
+
+	path, _ := parseFilepath("matrix")
+	Lists.Empty(path)
+	for _, line := range []string{
+		"(A) taxes $c=2025-01-10 $due=2025-01-20",
+		"(B) learn go $c=2025-01-10",
+		"(C) reply to mail $c=2025-01-10 $urgent",
+		"[A] tidy up $c=2025-01-10",
+		"(A) hidden $c=2025-01-10 $t=2025-01-16",
+	} {
+		require.NoError(t, AddTaskFromStr(line, path))
+	}
+
+	quadrants, err := matrixQuadrants([]string{path})
+	require.NoError(t, err)
+	var texts [4][]string
+	for ndx, quadrant := range quadrants {
+		for _, item := range quadrant {
+			texts[ndx] = append(texts[ndx], item.task.NormRegular())
+		}
+	}
+	assert.Equal([4][]string{{"taxes"}, {"learn go"}, {"reply to mail"}, {"tidy up"}}, texts)
+
+	viper.Set("matrix.importance", "C")
+	quadrants, _ = matrixQuadrants([]string{path})
+	assert.Len(quadrants[0], 2)
+
+	lines, err := RenderMatrix([]string{path}, 60)
+	require.NoError(t, err)
+	assert.True(strings.HasPrefix(lines[1], "┌─ do: urgent & important "))
+	for _, line := range lines[1:] {
+		assert.Equal(59, len([]rune(line)), line)
+	}
+	assert.Contains(lines[2], "matrix:0 (A) taxes")
+}
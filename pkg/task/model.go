@@ -11,24 +11,19 @@ import (
 	"unicode"
 )
 
-var (
-	rightNow        time.Time
-	oneMonthFromNow time.Time
-)
+var rightNow time.Time
 
 func AdjustTime() {
 	rightNow = time.Now()
-	oneMonthFromNow = rightNow.Add(30 * 24 * time.Hour)
 }
 
 func init() {
 	AdjustTime()
 }
 
+// whether the date is within the global urgency window
 func IsDateUrgent(dt *time.Time) bool {
-	return dt != nil &&
-		dt.After(rightNow) &&
-		dt.Before(oneMonthFromNow)
+	return dateUrgency(dt, urgencyWindow()) != UrgencyNone
 }
 
 type List struct {
@@ -60,9 +55,10 @@ func (l *lists) Init(path string, values ...*Task) {
 		(*l)[path] = new(List)
 		(*l)[path].EIDs = make(map[string]*Task)
 		(*l)[path].PIDs = make(map[*Task]string)
-		if len(values) > 0 {
-			(*l)[path].Tasks = append((*l)[path].Tasks, values...)
+		for _, task := range values {
+			task.list = path
 		}
+		(*l)[path].Tasks = append((*l)[path].Tasks, values...)
 	} else if len(values) > 0 {
 		l.Set(path, values)
 		cleanupRelations(path)
@@ -88,6 +84,7 @@ func (l *lists) Set(path string, tasks []*Task) {
 // append task to list if it exists
 func (l *lists) Append(path string, task *Task) {
 	l.Init(path)
+	task.list = path
 	(*l)[path].Tasks = append((*l)[path].Tasks, task)
 }
 
@@ -134,6 +131,7 @@ const (
 	TokenDuration
 	TokenProgress
 	TokenFormat
+	TokenRecurrence
 )
 
 type TokenDateValue struct {
@@ -141,6 +139,7 @@ type TokenDateValue struct {
 	RelKey string
 	RelVal *time.Time
 	Offset *time.Duration
+	Work   *WorkOffset // in place of Offset when counting working days or hours
 }
 
 type Token struct {
@@ -260,7 +259,12 @@ func (tk *Token) String() string {
 		}
 		return tk.unparseRelativeDatetime(nil)
 	case TokenProgress:
-		p, err := unparseProgress(*tk.Value.(*Progress))
+		// the text as written, aliases and all, until the count changes
+		val := tk.Value.(*Progress)
+		if prev, err := parseProgress(strings.TrimPrefix(*tk.raw, "$p=")); err == nil && *prev == *val {
+			return *tk.raw
+		}
+		p, err := unparseProgress(*val)
 		if err == nil {
 			return "$p=" + p
 		}
@@ -269,6 +273,15 @@ func (tk *Token) String() string {
 		if tk.Key == "focus" {
 			return "$focus"
 		}
+	case TokenRecurrence:
+		if tk.Key == "times" {
+			return fmt.Sprintf("$times=%d", *tk.Value.(*int))
+		}
+		rule := tk.Value.(*Recurrence).String()
+		if prev, err := parseRecurrence(strings.TrimPrefix(*tk.raw, "$rec=")); err == nil && prev.String() == rule {
+			return *tk.raw // as written until the rule changes
+		}
+		return "$rec=" + rule
 	}
 	return ""
 }
@@ -278,6 +291,7 @@ type Progress struct {
 	Category  string
 	Count     int
 	DoneCount int
+	rolledUp  bool // summed up from the children; see AggregateProgress
 }
 
 type Temporal struct {
@@ -287,6 +301,10 @@ type Temporal struct {
 	EndDate      *time.Time
 	Deadline     *time.Time
 	Every        *time.Duration
+	Recur        *Recurrence
+	Until        *time.Time
+	Times        *int       // remaining occurrences, the current one included
+	Threshold    *time.Time // the task is hidden until then
 }
 
 func (t *Temporal) getField(key string) (*time.Time, error) {
@@ -301,6 +319,10 @@ func (t *Temporal) getField(key string) (*time.Time, error) {
 		return t.EndDate, nil
 	case "dead":
 		return t.Deadline, nil
+	case "until":
+		return t.Until, nil
+	case "t":
+		return t.Threshold, nil
 	}
 	if key == "r" {
 		return nil, fmt.Errorf("key 'r' not supported since it's a slice of *time.Time")
@@ -318,6 +340,10 @@ func (t *Temporal) setField(key string, val *time.Time) error {
 		t.EndDate = val
 	case "dead":
 		t.Deadline = val
+	case "until":
+		t.Until = val
+	case "t":
+		t.Threshold = val
 	}
 	if key == "r" {
 		return fmt.Errorf("key 'r' not supported since it's a slice of *time.Time")
@@ -330,7 +356,8 @@ func (t *Temporal) setField(key string, val *time.Time) error {
 var temporalFormatFallback = map[string]string{
 	"c": "rn", "due": "rn",
 	"end": "due", "dead": "due",
-	"r": "rn",
+	"r": "rn", "until": "rn",
+	"t": "rn",
 }
 
 // The default fields for each temporal field used for
@@ -340,16 +367,20 @@ var temporalFallback = map[string]string{
 	"c":   "rn",
 	"due": "c",
 	"end": "due", "dead": "due", "r": "due",
+	"until": "due",
+	"t":     "c",
 }
 
 // which RelKeys each Key is allowed to reference
 var allowedTemporalRelations = map[string][]string{
-	"rn":   {"rn"},
-	"c":    {"rn"},
-	"due":  {"c", "rn"},
-	"end":  {"due", "c", "rn"},
-	"dead": {"due", "c", "rn"},
-	"r":    {"due", "c", "rn"},
+	"rn":    {"rn"},
+	"c":     {"rn"},
+	"due":   {"c", "rn"},
+	"end":   {"due", "c", "rn"},
+	"dead":  {"due", "c", "rn"},
+	"r":     {"due", "c", "rn"},
+	"until": {"due", "c", "rn"},
+	"t":     {"due", "c", "rn"},
 }
 
 type Format struct {
@@ -367,17 +398,25 @@ type Task struct {
 	Parent   *Task
 	Urgent   bool
 	MIT      *int
+	Est      *time.Duration // estimated effort ($est=)
 
 	Time *Temporal
 	Prog *Progress
 	Fmt  *Format
+
+	list string // the path of the list it belongs to
 }
 
+// whether the task is marked urgent or focused or any of its dates has an
+// urgency level; so an overdue task is urgent and the windows of its list
+// and hints apply, which is what matrix and plan classify tasks by
 func (t *Task) IsUrgent() bool {
-	return t.Urgent || t.MIT != nil ||
-		IsDateUrgent(t.Time.DueDate) ||
-		IsDateUrgent(t.Time.EndDate) ||
-		IsDateUrgent(t.Time.Deadline)
+	return t.Urgent || t.MIT != nil || t.Urgency() > UrgencyNone
+}
+
+// whether the task is hidden by its threshold
+func (t *Task) IsDeferred() bool {
+	return t.Time.Threshold != nil && t.Time.Threshold.After(rightNow)
 }
 
 func (t *Task) String() string {
@@ -421,6 +460,17 @@ func (t *Task) IsParentCollapsed() bool {
 	return false
 }
 
+func (t *Task) unfocus() {
+	if t.Fmt == nil || !t.Fmt.Focus {
+		return
+	}
+	t.Fmt.Focus = false
+	_, ndx := t.Tokens.Find(TkByTypeKey(TokenFormat, "focus"))
+	if ndx != -1 {
+		t.Tokens = slices.Delete(t.Tokens, ndx, ndx+1)
+	}
+}
+
 func (t *Task) revertIDtoText(key string) {
 	switch key {
 	case "id":
@@ -470,9 +520,9 @@ func (t *Task) update(new *Task) error {
 	if err != nil {
 		return err
 	}
-	id := t.ID
+	id, list := t.ID, t.list
 	*t = *new
-	t.ID = id
+	t.ID, t.list = id, list
 	return nil
 }
 
@@ -555,6 +605,45 @@ func (t *Task) updateDate(field string, newDt *time.Time) error {
 	return nil
 }
 
+// moves $due, $end, $dead, $t and every $r by diff; $until stays put. tokens relative to
+// another moved token are rebased so that their relative form is kept.
+func (t *Task) shiftDates(diff time.Duration) {
+	moved := make(map[*time.Time]*time.Time)
+	var tokens []*Token
+	t.Tokens.ForEach(func(tk *Token) {
+		if tk.Type != TokenDate || tk.Key == "c" || tk.Key == "until" {
+			return
+		}
+		val := tk.Value.(*TokenDateValue)
+		moved[val.Value] = utils.MkPtr(val.Value.Add(diff))
+		tokens = append(tokens, tk)
+	})
+	t.Time.Reminders = nil
+	for _, tk := range tokens {
+		val := tk.Value.(*TokenDateValue)
+		newDt := moved[val.Value]
+		if rel, ok := moved[val.RelVal]; ok {
+			val.RelVal = rel
+			if val.Work != nil { // working days don't move along with their base
+				newDt = utils.MkPtr(val.Work.apply(*rel))
+			}
+		} else if tk.Key != "r" {
+			t.updateDate(tk.Key, newDt)
+			continue
+		} else if val.RelKey == "" {
+			*tk.raw = fmt.Sprintf("$%s=%s", tk.Key, unparseAbsoluteDatetime(*newDt))
+		} else {
+			*tk.raw = tk.unparseRelativeDatetime(newDt)
+		}
+		val.Value = newDt
+		if tk.Key == "r" {
+			t.Time.Reminders = append(t.Time.Reminders, newDt)
+		} else {
+			t.Time.setField(tk.Key, newDt)
+		}
+	}
+}
+
 // this function is to be used in function that are turning the tokens of a task into a string
 func preprocessTaskStrings(t *Task, index int, out *strings.Builder) {
 	if index > 0 {
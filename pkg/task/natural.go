@@ -0,0 +1,205 @@
+package task
+
+import (
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+/*
+natural datetime: [<day>][-<clock>] | in-<duration>
+day:
+
+	now, today, tomorrow|tmr, yesterday
+	<weekday>:        the first one after today; e.g. fri, friday
+	this-<weekday>:   the one within the current week
+	next-<weekday>:   the one within the next week
+	next-week|month|year: the start of the next week, month or year
+	sow, eow:         start and end of the current week; based on 'time.week-start'
+	som, eom:         start and end of the current month
+	soy, eoy:         start and end of the current year
+	eod:              end of today
+
+clock: 9am, 9:30pm, 21:30, 21, noon, midnight
+
+days start at midnight and ends (eod, eow, ...) are at 23:59:59 unless a clock is given.
+*/
+func parseNaturalDatetime(dt string) (*time.Time, error) {
+	dt = strings.ToLower(dt)
+	if dur, ok := strings.CutPrefix(dt, "in-"); ok {
+		duration, err := parseDuration(dur)
+		if err != nil {
+			return nil, err
+		}
+		return utils.MkPtr(rightNow.Add(*duration)), nil
+	}
+
+	parts := strings.Split(dt, "-")
+	day, n, err := parseNaturalDay(parts)
+	if err != nil {
+		return nil, err
+	}
+	parts = parts[n:]
+	switch len(parts) {
+	case 0:
+		if n == 0 {
+			return nil, fmt.Errorf("%w: empty natural datetime", terrors.ErrParse)
+		}
+		return &day, nil
+	case 1:
+		hour, minute, err := parseClock(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 { // only a clock means today
+			day = startOfDay(rightNow)
+		}
+		out := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, LocalZone())
+		return &out, nil
+	}
+	return nil, fmt.Errorf("%w: invalid natural datetime: '%s'", terrors.ErrParse, dt)
+}
+
+// parses the day at the start of parts; returns how many parts were used
+func parseNaturalDay(parts []string) (time.Time, int, error) {
+	today := startOfDay(rightNow)
+	switch parts[0] {
+	case "now":
+		return rightNow, 1, nil
+	case "today":
+		return today, 1, nil
+	case "tomorrow", "tmr":
+		return today.AddDate(0, 0, 1), 1, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), 1, nil
+	case "eod":
+		return endOfDay(today), 1, nil
+	case "sow":
+		return startOfWeek(today), 1, nil
+	case "eow":
+		return endOfDay(startOfWeek(today).AddDate(0, 0, 6)), 1, nil
+	case "som":
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, LocalZone()), 1, nil
+	case "eom":
+		return endOfDay(time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, LocalZone())), 1, nil
+	case "soy":
+		return time.Date(today.Year(), 1, 1, 0, 0, 0, 0, LocalZone()), 1, nil
+	case "eoy":
+		return endOfDay(time.Date(today.Year(), 12, 31, 0, 0, 0, 0, LocalZone())), 1, nil
+	case "this", "next":
+		if len(parts) < 2 {
+			return time.Time{}, 0, fmt.Errorf("%w: '%s' must be followed by a weekday, week, month or year", terrors.ErrParse, parts[0])
+		}
+		sow := startOfWeek(today)
+		if parts[0] == "next" {
+			switch parts[1] {
+			case "week":
+				return sow.AddDate(0, 0, 7), 2, nil
+			case "month":
+				return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, LocalZone()), 2, nil
+			case "year":
+				return time.Date(today.Year()+1, 1, 1, 0, 0, 0, 0, LocalZone()), 2, nil
+			}
+			sow = sow.AddDate(0, 0, 7)
+		}
+		wd, err := parseWeekday(parts[1])
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		return sow.AddDate(0, 0, (int(wd)-int(sow.Weekday())+7)%7), 2, nil
+	}
+	if wd, err := parseWeekday(parts[0]); err == nil {
+		diff := (int(wd) - int(today.Weekday()) + 7) % 7
+		if diff == 0 {
+			diff = 7
+		}
+		return today.AddDate(0, 0, diff), 1, nil
+	}
+	if _, _, err := parseClock(parts[0]); err == nil {
+		return today, 0, nil
+	}
+	return time.Time{}, 0, fmt.Errorf("%w: unknown natural datetime '%s'", terrors.ErrParse, strings.Join(parts, "-"))
+}
+
+// full names or prefixes of at least 3 letters in any case; e.g. Fri, thurs, saturday
+func ParseWeekday(s string) (time.Weekday, error) {
+	return parseWeekday(strings.ToLower(s))
+}
+
+// full names or prefixes of at least 3 letters; e.g. fri, thurs, saturday
+func parseWeekday(s string) (time.Weekday, error) {
+	if len(s) >= 3 {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if strings.HasPrefix(strings.ToLower(wd.String()), s) {
+				return wd, nil
+			}
+		}
+	}
+	return time.Sunday, fmt.Errorf("%w: invalid weekday '%s'", terrors.ErrParse, s)
+}
+
+func parseClock(s string) (int, int, error) {
+	switch s {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	invalid := fmt.Errorf("%w: invalid clock '%s'", terrors.ErrParse, s)
+	meridiem := ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		meridiem, s = s[len(s)-2:], s[:len(s)-2]
+	}
+	hourStr, minuteStr, hasMinute := strings.Cut(s, ":")
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || len(hourStr) > 2 {
+		return 0, 0, invalid
+	}
+	minute := 0
+	if hasMinute {
+		if minute, err = strconv.Atoi(minuteStr); err != nil || len(minuteStr) != 2 || minute >= 60 {
+			return 0, 0, invalid
+		}
+	}
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return 0, 0, invalid
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	} else if hour >= 24 {
+		return 0, 0, invalid
+	}
+	return hour, minute, nil
+}
+
+// the configured first day of the week
+func weekStart() time.Weekday {
+	wd, err := parseWeekday(strings.ToLower(viper.GetString("time.week-start")))
+	if err != nil {
+		return time.Saturday
+	}
+	return wd
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.In(LocalZone())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, LocalZone())
+}
+
+func endOfDay(t time.Time) time.Time {
+	t = t.In(LocalZone())
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, LocalZone())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	t = startOfDay(t)
+	return t.AddDate(0, 0, -((int(t.Weekday()) - int(weekStart()) + 7) % 7))
+}
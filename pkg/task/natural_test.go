@@ -0,0 +1,86 @@
+package task
+
+import (
+	"dotxt/pkg/terrors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNaturalDatetime(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	defer viper.Set("time.week-start", viper.GetString("time.week-start"))
+	viper.Set("time.week-start", "mon")
+	rightNow = time.Date(2025, 5, 7, 14, 30, 0, 0, time.Local) // wednesday
+	date := func(month time.Month, day, hour, min, sec int) time.Time {
+		return time.Date(2025, month, day, hour, min, sec, 0, time.Local)
+	}
+	helper := func(dt string) time.Time {
+		out, err := parseNaturalDatetime(dt)
+		if assert.NoError(err, dt) {
+			return *out
+		}
+		return time.Time{}
+	}
+
+	for dt, expected := range map[string]time.Time{
+		"now":           rightNow,
+		"today":         date(5, 7, 0, 0, 0),
+		"Tomorrow":      date(5, 8, 0, 0, 0),
+		"tmr-9am":       date(5, 8, 9, 0, 0),
+		"yesterday":     date(5, 6, 0, 0, 0),
+		"fri":           date(5, 9, 0, 0, 0),
+		"wed":           date(5, 14, 0, 0, 0),
+		"mon-9am":       date(5, 12, 9, 0, 0),
+		"thurs-9:30pm":  date(5, 8, 21, 30, 0),
+		"this-mon":      date(5, 5, 0, 0, 0),
+		"this-sun":      date(5, 11, 0, 0, 0),
+		"next-fri":      date(5, 16, 0, 0, 0),
+		"next-week":     date(5, 12, 0, 0, 0),
+		"next-month":    date(6, 1, 0, 0, 0),
+		"next-year":     time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+		"eod":           date(5, 7, 23, 59, 59),
+		"sow":           date(5, 5, 0, 0, 0),
+		"eow":           date(5, 11, 23, 59, 59),
+		"eow-5pm":       date(5, 11, 17, 0, 0),
+		"som":           date(5, 1, 0, 0, 0),
+		"eom":           date(5, 31, 23, 59, 59),
+		"soy":           date(1, 1, 0, 0, 0),
+		"eoy":           date(12, 31, 23, 59, 59),
+		"in-3d":         date(5, 10, 14, 30, 0),
+		"in-2h":         date(5, 7, 16, 30, 0),
+		"18:45":         date(5, 7, 18, 45, 0),
+		"noon":          date(5, 7, 12, 0, 0),
+		"12am":          date(5, 7, 0, 0, 0),
+		"tomorrow-12pm": date(5, 8, 12, 0, 0),
+	} {
+		assert.Equal(expected, helper(dt), dt)
+	}
+
+	t.Run("week start", func(t *testing.T) {
+		viper.Set("time.week-start", "sat")
+		assert.Equal(date(5, 3, 0, 0, 0), helper("sow"))
+		assert.Equal(date(5, 9, 23, 59, 59), helper("eow"))
+		assert.Equal(date(5, 10, 0, 0, 0), helper("next-sat"))
+		viper.Set("time.week-start", "mon")
+	})
+
+	for _, dt := range []string{"", "someday", "next", "next-blah", "fri-13pm", "fri-9-am", "mon-9:5", "in-3x", "tomorrow-noon-1"} {
+		_, err := parseNaturalDatetime(dt)
+		assert.ErrorIs(err, terrors.ErrParse, dt)
+	}
+}
+
+func TestParseNaturalToken(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 5, 7, 14, 30, 0, 0, time.Local)
+	task, err := ParseTask(nil, "call $c=2025-05-01 $due=tomorrow-5pm $r=due:-1h $dead=eow")
+	assert.NoError(err)
+	assert.Equal(time.Date(2025, 5, 8, 17, 0, 0, 0, time.Local), *task.Time.DueDate)
+	assert.Equal(time.Date(2025, 5, 8, 16, 0, 0, 0, time.Local), *task.Time.Reminders[0])
+	assert.Equal("call $due=2025-05-08T17 $r=due:-1h $dead=2025-05-09T23-59-59", task.Norm()) // weeks start on saturday
+}
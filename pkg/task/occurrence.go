@@ -0,0 +1,115 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"slices"
+	"time"
+)
+
+// the most instances of a single series expanded within a window
+const maxOccurrences = 5000
+
+// a single instance of a task that has a due date;
+// recurring tasks yield one for every instance of their series.
+type Occurrence struct {
+	Task      *Task
+	List      string // name of the list
+	Due       time.Time
+	End       *time.Time
+	Dead      *time.Time
+	Reminders []time.Time
+}
+
+// the first moment the occurrence concerns; reminders may precede the due date
+func (o *Occurrence) First() time.Time {
+	first := o.Due
+	for _, dt := range o.Reminders {
+		if dt.Before(first) {
+			first = dt
+		}
+	}
+	return first
+}
+
+// the last moment the occurrence spans
+func (o *Occurrence) Last() time.Time {
+	last := o.Due
+	for _, dt := range []*time.Time{o.End, o.Dead} {
+		if dt != nil && dt.After(last) {
+			last = *dt
+		}
+	}
+	for _, dt := range o.Reminders {
+		if dt.After(last) {
+			last = dt
+		}
+	}
+	return last
+}
+
+// whether the occurrence has any part within [from, to)
+func (o *Occurrence) overlaps(from, to time.Time) bool {
+	return o.First().Before(to) && !o.Last().Before(from)
+}
+
+// the occurrences of the task overlapping [from, to); the series of recurring
+// tasks is expanded from the current due date on within its bounds.
+func (t *Task) occurrences(list string, from, to time.Time) []*Occurrence {
+	if t.Time == nil || t.Time.DueDate == nil {
+		return nil
+	}
+	due := *t.Time.DueDate
+	at := func(next time.Time) *Occurrence {
+		diff := next.Sub(due)
+		occ := &Occurrence{Task: t, List: list, Due: next}
+		if t.Time.EndDate != nil {
+			occ.End = utils.MkPtr(t.Time.EndDate.Add(diff))
+		}
+		if t.Time.Deadline != nil {
+			occ.Dead = utils.MkPtr(t.Time.Deadline.Add(diff))
+		}
+		for _, r := range t.Time.Reminders {
+			occ.Reminders = append(occ.Reminders, r.Add(diff))
+		}
+		return occ
+	}
+	var out []*Occurrence
+	cur := due
+	for count := 0; count < maxOccurrences; count++ {
+		occ := at(cur)
+		if !occ.First().Before(to) {
+			break
+		}
+		if occ.overlaps(from, to) {
+			out = append(out, occ)
+		}
+		if !t.recurs() || (t.Time.Times != nil && count+1 >= *t.Time.Times) {
+			break
+		}
+		next, ok := t.Time.nextOccurrence(due, cur)
+		if !ok || (t.Time.Until != nil && next.After(*t.Time.Until)) {
+			break
+		}
+		cur = next
+	}
+	return out
+}
+
+// the occurrences of the tasks of the given lists overlapping [from, to), ordered by due
+func Occurrences(paths []string, from, to time.Time) ([]*Occurrence, error) {
+	var out []*Occurrence
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		list := ListName(path)
+		for _, t := range Lists[path].Tasks {
+			out = append(out, t.occurrences(list, from, to)...)
+		}
+	}
+	slices.SortStableFunc(out, func(l, r *Occurrence) int {
+		return l.Due.Compare(r.Due)
+	})
+	return out, nil
+}
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrences(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	date := func(day, hour int) time.Time {
+		return time.Date(2025, 1, day, hour, 0, 0, 0, time.Local)
+	}
+
+	path, _ := parseFilepath("occurrences")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("daily $c=2025-01-15T09 $due=2025-01-16T09 $end=due:1h $every=1d $times=3", path))
+	require.NoError(t, AddTaskFromStr("event $c=2025-01-05T09 $due=2025-01-10T09 $end=2025-01-20T09", path))
+	require.NoError(t, AddTaskFromStr("report $c=2025-01-15T09 $due=2025-01-16T09 $dead=2025-01-25T09", path))
+	require.NoError(t, AddTaskFromStr("later $c=2025-01-15T09 $due=2025-02-16T09", path))
+	require.NoError(t, AddTaskFromStr("no due", path))
+
+	occs, err := Occurrences([]string{path}, date(16, 0), date(23, 0))
+	require.NoError(t, err)
+	var dues []time.Time
+	for _, occ := range occs {
+		dues = append(dues, occ.Due)
+	}
+	assert.Equal([]time.Time{date(10, 9), date(16, 9), date(16, 9), date(17, 9), date(18, 9)}, dues)
+	assert.Equal("event", occs[0].Task.NormRegular())
+	assert.Equal(date(20, 9), occs[0].Last())
+	if assert.NotNil(occs[4].End) {
+		assert.Equal(date(18, 10), *occs[4].End)
+	}
+
+	occs, err = Occurrences([]string{path}, date(24, 0), date(25, 0))
+	require.NoError(t, err)
+	if assert.Len(occs, 1) {
+		assert.Equal("report", occs[0].Task.NormRegular())
+	}
+}
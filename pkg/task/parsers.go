@@ -61,6 +61,14 @@ date:
 				- %b
 */
 func parseAbsoluteDatetime(absDt string) (*time.Time, error) {
+	loc := LocalZone()
+	if ndx := strings.LastIndexByte(absDt, '@'); ndx != -1 {
+		zone, err := utils.ParseZone(absDt[ndx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", terrors.ErrParse, err)
+		}
+		loc, absDt = zone, absDt[:ndx]
+	}
 	if absDt == "" {
 		return nil, fmt.Errorf("%w: empty", terrors.ErrParse)
 	}
@@ -171,15 +179,30 @@ func parseAbsoluteDatetime(absDt string) (*time.Time, error) {
 		dateStr = fmt.Sprintf("%s-01-01", rightNow.Format("2006"))
 	}
 
-	t, err := time.ParseInLocation("2006-01-02T15-04-05", fmt.Sprintf("%sT%s", dateStr, timeStr), time.Local)
+	t, err := time.ParseInLocation("2006-01-02T15-04-05", fmt.Sprintf("%sT%s", dateStr, timeStr), loc)
 	if err != nil {
 		return nil, err
 	}
 	return &t, nil
 }
 
+// a datetime given on the command line; absolute, natural
+// or a duration relative to now (e.g. 2025-05-05, tomorrow, 3d)
+func ParseDatetime(value string) (*time.Time, error) {
+	if dt, err := parseAbsoluteDatetime(value); err == nil {
+		return dt, nil
+	}
+	if dt, err := parseNaturalDatetime(value); err == nil {
+		return dt, nil
+	}
+	if dur, err := parseDuration(value); err == nil {
+		return utils.MkPtr(rightNow.Add(*dur)), nil
+	}
+	return nil, fmt.Errorf("%w: invalid datetime '%s'", terrors.ErrParse, value)
+}
+
 /*
-datetime: [date]T[[time]]
+datetime: [date]T[[time]][@zone]
 date:
 
 	if month == 1 and day == 1: %Y
@@ -192,8 +215,16 @@ time:
 	else if minute == 0 and second == 0:       	  T%H
 	else if second == 0:						  T%H-%M
 	else:										  T%H-%M-%S
+
+zone:
+
+	omitted for the local zone ('time.zone' or the system's)
+	otherwise the zone the datetime was given in; e.g. @UTC, @+0330, @Europe/Berlin
 */
 func unparseAbsoluteDatetime(absDt time.Time) string {
+	if absDt.Location() == time.Local {
+		absDt = absDt.In(LocalZone())
+	}
 	var dateStr string
 	if absDt.Month() == 1 && absDt.Day() == 1 {
 		dateStr = absDt.Format("2006")
@@ -214,7 +245,12 @@ func unparseAbsoluteDatetime(absDt time.Time) string {
 	if len(timeStr) > 0 {
 		timeStr = "T" + timeStr
 	}
-	return fmt.Sprintf("%s%s", dateStr, timeStr)
+	return fmt.Sprintf("%s%s%s", dateStr, timeStr, zoneSuffix(absDt))
+}
+
+// a duration given on the command line; e.g. 30M, 1h30M
+func ParseDuration(dur string) (*time.Duration, error) {
+	return parseDuration(dur)
 }
 
 func parseDuration(dur string) (*time.Duration, error) {
@@ -231,6 +267,9 @@ func parseDuration(dur string) (*time.Duration, error) {
 	if dur == "0" {
 		return utils.MkPtr(time.Duration(0)), nil
 	}
+	if strings.HasSuffix(dur, "bd") || strings.HasSuffix(dur, "bh") {
+		return nil, fmt.Errorf("%w: working days and hours only offset relative datetimes (e.g. $dead=due:3bd) not durations '%s'", terrors.ErrParse, dur)
+	}
 
 	const day = 24 * time.Hour
 	var duration float64
@@ -345,7 +384,13 @@ func (tk *Token) unparseRelativeDatetime(val *time.Time) string {
 	if relVal == nil {
 		relVal = &rightNow
 	}
-	newDtTxt := unparseRelativeDatetime(*val, *relVal)
+	var newDtTxt string
+	if tkDt.Work != nil && tkDt.Work.apply(*relVal).Equal(*val) {
+		newDtTxt = tkDt.Work.String()
+	} else {
+		tkDt.Work = nil
+		newDtTxt = unparseRelativeDatetime(*val, *relVal)
+	}
 	if strings.ContainsRune(*tk.raw, ':') {
 		newDtTxt = fmt.Sprintf("%s:%s", tkDt.RelKey, newDtTxt)
 	}
@@ -398,6 +443,10 @@ func parseProgress(token string) (*Progress, error) {
 	if unit == "" {
 		return nil, fmt.Errorf("%w: %w: $progress: unit is empty", terrors.ErrParse, terrors.ErrValue)
 	}
+	unit = resolveUnit(unit)
+	if category == "" {
+		category = unitCategory(unit)
+	}
 	doneCountInt, err := strconv.Atoi(doneCount)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w: $progress: doneCount to int: %w", terrors.ErrParse, terrors.ErrValue, err)
@@ -475,7 +524,7 @@ func resolveDates(tokens []*Token) []error {
 			continue
 		}
 
-		if tdv.Offset == nil || tdv.RelKey == "" {
+		if (tdv.Offset == nil && tdv.Work == nil) || tdv.RelKey == "" {
 			dateToTextToken(tk)
 			continue
 		}
@@ -498,7 +547,7 @@ func resolveDates(tokens []*Token) []error {
 	for len(resolved)-1 < dtCount { // ?
 		changed := false
 		// this order is based on temporalFallback and please review this if you change that
-		for _, key := range append([]string{"c", "due", "end", "dead"}, rKeys...) {
+		for _, key := range append([]string{"c", "due", "end", "dead", "until", "t"}, rKeys...) {
 			tk, ok := nodes[key]
 			if !ok { // validate relative
 				continue
@@ -511,7 +560,11 @@ func resolveDates(tokens []*Token) []error {
 			for range 3 { // 3 is the max depth from temporalFallback's current state
 				if base, ok := resolved[ref]; ok {
 					tdv.RelVal = base.Value
-					tdv.Value = utils.MkPtr(tdv.RelVal.Add(*tdv.Offset))
+					if tdv.Work != nil {
+						tdv.Value = utils.MkPtr(tdv.Work.apply(*tdv.RelVal))
+					} else {
+						tdv.Value = utils.MkPtr(tdv.RelVal.Add(*tdv.Offset))
+					}
 					resolved[key] = tdv
 					changed = true
 				} else {
@@ -773,17 +826,28 @@ func parseTokens(line string) ([]*Token, []error) {
 					Type: TokenID, raw: &tokenStr,
 					Key: k, Value: &value,
 				})
-			case "c", "due", "end", "dead", "r":
+			case "c", "due", "end", "dead", "r", "until", "t":
 				var err error
 				var tkValue TokenDateValue
 				tkValue.Value, err = parseAbsoluteDatetime(value)
 				if err != nil {
 					tkValue.RelKey, tkValue.Offset, err = parseTmpRelativeDatetime(key, value)
-					if err != nil {
-						handleTokenText(tokenStr, fmt.Errorf("%w: $%s", err, key))
-						continue
+				}
+				if err != nil {
+					if relKey, work, wErr := parseTmpWorkRelativeDatetime(key, value); wErr == nil {
+						tkValue.RelKey, tkValue.Work, err = relKey, work, nil
+					}
+				}
+				if err != nil {
+					// natural values are resolved right away and stored in the absolute form
+					if natural, nErr := parseNaturalDatetime(value); nErr == nil {
+						tkValue.Value, err = natural, nil
 					}
 				}
+				if err != nil {
+					handleTokenText(tokenStr, fmt.Errorf("%w: $%s", err, key))
+					continue
+				}
 				tokens = append(tokens, &Token{
 					Type: TokenDate, raw: &tokenStr,
 					Key: key, Value: &tkValue,
@@ -800,6 +864,39 @@ func parseTokens(line string) ([]*Token, []error) {
 					Type: TokenDuration, raw: &tokenStr,
 					Key: key, Value: duration,
 				})
+			case "est":
+				duration, err := parseDuration(value)
+				if err != nil {
+					handleTokenText(tokenStr, fmt.Errorf("%w: $est: %w", terrors.ErrParse, err))
+					continue
+				} else if *duration <= 0 {
+					handleTokenText(tokenStr, fmt.Errorf("%w: %w: $est must be positive not '%s'", terrors.ErrParse, terrors.ErrValue, value))
+					continue
+				}
+				tokens = append(tokens, &Token{
+					Type: TokenDuration, raw: &tokenStr,
+					Key: key, Value: duration,
+				})
+			case "rec":
+				rec, err := parseRecurrence(value)
+				if err != nil {
+					handleTokenText(tokenStr, err)
+					continue
+				}
+				tokens = append(tokens, &Token{
+					Type: TokenRecurrence, raw: &tokenStr,
+					Key: key, Value: rec,
+				})
+			case "times":
+				times, err := strconv.Atoi(value)
+				if err != nil || times < 1 {
+					handleTokenText(tokenStr, fmt.Errorf("%w: %w: $times must be a positive integer not '%s'", terrors.ErrParse, terrors.ErrValue, value))
+					continue
+				}
+				tokens = append(tokens, &Token{
+					Type: TokenRecurrence, raw: &tokenStr,
+					Key: key, Value: &times,
+				})
 			case "p":
 				progress, err := parseProgress(value)
 				if err != nil {
@@ -901,9 +998,25 @@ func ParseTask(id *int, line string) (*Task, error) {
 				task.Time.EndDate = token.Value.(*TokenDateValue).Value
 			case "dead":
 				task.Time.Deadline = token.Value.(*TokenDateValue).Value
+			case "until":
+				task.Time.Until = token.Value.(*TokenDateValue).Value
+			case "t":
+				task.Time.Threshold = token.Value.(*TokenDateValue).Value
 			}
 		case TokenDuration:
-			task.Time.Every = token.Value.(*time.Duration)
+			switch token.Key {
+			case "every":
+				task.Time.Every = token.Value.(*time.Duration)
+			case "est":
+				task.Est = token.Value.(*time.Duration)
+			}
+		case TokenRecurrence:
+			switch token.Key {
+			case "rec":
+				task.Time.Recur = token.Value.(*Recurrence)
+			case "times":
+				task.Time.Times = token.Value.(*int)
+			}
 		case TokenProgress:
 			task.Prog = token.Value.(*Progress)
 		case TokenFormat:
@@ -929,6 +1042,24 @@ func ParseTask(id *int, line string) (*Task, error) {
 			},
 		})
 	}
+	if task.Time.Recur != nil && task.Time.Every != nil {
+		tk, _ := task.Tokens.Find(TkByTypeKey(TokenDuration, "every"))
+		if tk != nil {
+			dateToTextToken(tk)
+			task.Time.Every = nil
+		}
+	}
+	if task.Time.DueDate == nil && task.Time.Recur != nil {
+		if next, ok := task.Time.Recur.Next(*task.Time.CreationDate, *task.Time.CreationDate); ok {
+			task.Time.DueDate = &next
+			task.Tokens = append(task.Tokens, &Token{
+				Type:  TokenDate,
+				Key:   "due",
+				raw:   utils.MkPtr(fmt.Sprintf("$due=%s", unparseAbsoluteDatetime(next))),
+				Value: &TokenDateValue{Value: task.Time.DueDate},
+			})
+		}
+	}
 	if task.Time.DueDate == nil && task.Time.Every != nil {
 		task.Time.DueDate = utils.MkPtr(task.Time.CreationDate.Add(*task.Time.Every))
 		task.Tokens = append(task.Tokens, &Token{
@@ -941,6 +1072,23 @@ func ParseTask(id *int, line string) (*Task, error) {
 			},
 		})
 	}
+	if task.Time.Recur != nil && task.Time.DueDate != nil {
+		task.Time.Recur.pin(*task.Time.DueDate)
+	}
+	if task.Time.Until != nil || task.Time.Times != nil {
+		if task.Time.Every == nil && task.Time.Recur == nil {
+			for _, tk := range *task.Tokens.Filter(TkByTypeKey(TokenDate, "until").
+				Or(TkByTypeKey(TokenRecurrence, "times"))) {
+				dateToTextToken(tk)
+			}
+			task.Time.Until, task.Time.Times = nil, nil
+		} else if tk, _ := task.Tokens.Find(TkByTypeKey(TokenDate, "until")); tk != nil {
+			// the bound is kept absolute so that advancing the due date doesn't move it
+			val := tk.Value.(*TokenDateValue)
+			val.RelKey, val.RelVal, val.Offset, val.Work = "", nil, nil, nil
+			*tk.raw = fmt.Sprintf("$until=%s", unparseAbsoluteDatetime(*val.Value))
+		}
+	}
 	if task.Time.DueDate != nil && !task.Time.DueDate.After(*task.Time.CreationDate) {
 		tk, _ := task.Tokens.Find(TkByTypeKey(TokenDate, "due"))
 		if tk != nil {
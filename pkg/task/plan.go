@@ -0,0 +1,195 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// a task proposed for today and why
+type PlanItem struct {
+	Task   *Task
+	Path   string
+	Reason string // mit, overdue, running, today or urgent
+}
+
+var planReasons = []string{"mit", "overdue", "running", "today", "urgent"}
+
+// why the task belongs to today; empty if it does not
+func planReason(t *Task) string {
+	switch {
+	case t.MIT != nil:
+		return "mit"
+	case t.IsDeferred():
+		return ""
+	case t.Urgency() == UrgencyOverdue:
+		return "overdue"
+	case t.Time.DueDate != nil && !t.Time.DueDate.After(rightNow) &&
+		t.Time.EndDate != nil && t.Time.EndDate.After(rightNow):
+		return "running"
+	case t.Time.DueDate != nil && t.Time.DueDate.Before(startOfDay(rightNow).AddDate(0, 0, 1)) &&
+		t.Time.DueDate.After(rightNow):
+		return "today"
+	}
+	if tk, _ := t.Tokens.Find(TkByTypeKey(TokenPriority, "urgent")); tk != nil {
+		return "urgent"
+	}
+	return ""
+}
+
+// the tasks of the given lists that make up today; the current mits by
+// their rank, then overdue tasks, running events, tasks due today and
+// the ones marked urgent, each by urgency.
+func ProposePlan(paths []string) ([]*PlanItem, error) {
+	var out []*PlanItem
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range Lists[path].Tasks {
+			if reason := planReason(t); reason != "" {
+				out = append(out, &PlanItem{Task: t, Path: path, Reason: reason})
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(l, r *PlanItem) int {
+		if v := slices.Index(planReasons, l.Reason) - slices.Index(planReasons, r.Reason); v != 0 {
+			return v
+		}
+		if v := sortUrgency(l.Task, r.Task); v != 2 {
+			return v
+		}
+		return 0
+	})
+	return out, nil
+}
+
+// the date of the last accepted plan is kept under _etc
+func planPath() string {
+	return filepath.Join(etcDir(), "plan")
+}
+
+// the day of the last accepted plan; nil if there is none
+func planDay() (*time.Time, error) {
+	data, err := os.ReadFile(planPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(string(data)), LocalZone())
+	if err != nil {
+		return nil, nil
+	}
+	return &day, nil
+}
+
+// sets the mit rank of the task; nil removes it
+func (t *Task) setMIT(rank *int) error {
+	if _, ndx := t.Tokens.Find(TkByTypeKey(TokenPriority, "mit")); ndx != -1 {
+		t.Tokens = slices.Delete(t.Tokens, ndx, ndx+1)
+	}
+	if rank == nil {
+		return t.updateByModifyingText("", "")
+	}
+	return t.updateByModifyingText("", fmt.Sprintf("$mit=%d", *rank))
+}
+
+// ranks the items from 1 in the given order as the mits of today;
+// the other mits of the given lists are removed.
+func AcceptPlan(paths []string, items []*PlanItem) error {
+	ranks := make(map[*Task]int)
+	for ndx, item := range items {
+		ranks[item.Task] = ndx + 1
+	}
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return err
+		}
+		for _, t := range Lists[path].Tasks {
+			if rank, ok := ranks[t]; ok {
+				if err := t.setMIT(&rank); err != nil {
+					return err
+				}
+			} else if t.MIT != nil {
+				if err := t.setMIT(nil); err != nil {
+					return err
+				}
+			}
+		}
+		cleanupRelations(path)
+	}
+	if err := mkDirs(""); err != nil {
+		return err
+	}
+	return os.WriteFile(planPath(), []byte(startOfDay(rightNow).Format("2006-01-02")), 0o644)
+}
+
+// removes the mits of the given lists if the last plan was made before today;
+// returns whether they were removed, in which case the lists must be stored.
+func ClearStaleMITs(paths []string) (bool, error) {
+	day, err := planDay()
+	if err != nil || day == nil || !day.Before(startOfDay(rightNow)) {
+		return false, err
+	}
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return false, err
+		}
+		for _, t := range Lists[path].Tasks {
+			if t.MIT != nil {
+				if err := t.setMIT(nil); err != nil {
+					return false, err
+				}
+			}
+		}
+		cleanupRelations(path)
+	}
+	return true, os.Remove(planPath())
+}
+
+// the proposed plan as numbered lines
+func RenderPlan(items []*PlanItem) []string {
+	header := "> plan | " + startOfDay(rightNow).Format("2006-01-02") + " "
+	lines := []string{colorize("print.color-header", header+strings.Repeat("—", max(40-utils.RuneCount(header), 0)))}
+	for ndx, item := range items {
+		lines = append(lines, colorize("print.color-index", fmt.Sprintf("%2d. %-7s %s:", ndx+1, item.Reason, ListName(item.Path)))+
+			item.Task.Render().stringify(true, -1))
+	}
+	return lines
+}
+
+// the mits of the given lists by rank
+func RenderToday(paths []string) ([]string, error) {
+	var items []*PlanItem
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range Lists[path].Tasks {
+			if t.MIT != nil {
+				items = append(items, &PlanItem{Task: t, Path: path, Reason: "mit"})
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(l, r *PlanItem) int {
+		return *l.Task.MIT - *r.Task.MIT
+	})
+	header := "> today | " + startOfDay(rightNow).Format("2006-01-02") + " "
+	lines := []string{colorize("print.color-header", header+strings.Repeat("—", max(40-utils.RuneCount(header), 0)))}
+	for _, item := range items {
+		lines = append(lines, colorize("print.color-mit", fmt.Sprintf("%2d. ", *item.Task.MIT))+
+			colorize("print.color-index", ListName(item.Path)+":")+
+			item.Task.Render().stringify(true, -1))
+	}
+	return lines, nil
+}
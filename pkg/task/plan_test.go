@@ -0,0 +1,74 @@
+package task
+
+import (
+	"dotxt/config"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	path, _ := parseFilepath("plan")
+	Lists.Empty(path)
+	for _, line := range []string{
+		"someday $c=2025-01-10",
+		"taxes $c=2025-01-10 $due=2025-01-14",
+		"standup $c=2025-01-10 $due=2025-01-15T08 $end=2025-01-15T10",
+		"dentist $c=2025-01-10 $due=2025-01-15T16",
+		"call $c=2025-01-10 $urgent",
+		"write $c=2025-01-10 $mit=3",
+		"hidden $c=2025-01-10 $due=2025-01-15T16 $t=2025-01-16",
+	} {
+		require.NoError(t, AddTaskFromStr(line, path))
+	}
+
+	items, err := ProposePlan([]string{path})
+	require.NoError(t, err)
+	var texts, reasons []string
+	for _, item := range items {
+		texts = append(texts, item.Task.NormRegular())
+		reasons = append(reasons, item.Reason)
+	}
+	assert.Equal([]string{"write", "taxes", "standup", "dentist", "call"}, texts)
+	assert.Equal([]string{"mit", "overdue", "running", "today", "urgent"}, reasons)
+
+	// reordered and without the urgent one
+	require.NoError(t, AcceptPlan([]string{path}, []*PlanItem{items[3], items[1], items[0]}))
+	mits := make(map[string]int)
+	for _, task := range Lists[path].Tasks {
+		if task.MIT != nil {
+			mits[task.NormRegular()] = *task.MIT
+		}
+	}
+	assert.Equal(map[string]int{"dentist": 1, "taxes": 2, "write": 3}, mits)
+	lines, err := RenderToday([]string{path})
+	require.NoError(t, err)
+	assert.Len(lines, 4)
+
+	cleared, err := ClearStaleMITs([]string{path})
+	require.NoError(t, err)
+	assert.False(cleared)
+
+	rightNow = rightNow.AddDate(0, 0, 1)
+	cleared, err = ClearStaleMITs([]string{path})
+	require.NoError(t, err)
+	assert.True(cleared)
+	for _, task := range Lists[path].Tasks {
+		assert.Nil(task.MIT)
+	}
+	_, err = os.Stat(planPath())
+	assert.True(os.IsNotExist(err))
+}
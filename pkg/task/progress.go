@@ -0,0 +1,365 @@
+package task
+
+import (
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// 'progress.units'; the category of each registered unit
+func unitCategories() map[string]string {
+	return viper.GetStringMapString("progress.units")
+}
+
+// 'progress.aliases'; the unit each alias stands for
+func unitAliases() map[string]string {
+	return viper.GetStringMapString("progress.aliases")
+}
+
+// the possible singulars of a plural; "entries" -> "entry", "boxes" -> "box", "pages" -> "page"
+func singulars(word string) []string {
+	var out []string
+	for _, suffix := range []struct{ plural, singular string }{{"ies", "y"}, {"es", ""}, {"s", ""}} {
+		if stem, ok := strings.CutSuffix(word, suffix.plural); ok && stem != "" {
+			out = append(out, stem+suffix.singular)
+		}
+	}
+	return out
+}
+
+// the registered unit the given one stands for; aliases are followed and the
+// plurals of registered units and aliases are taken as them. units that
+// aren't registered are kept as they are.
+func resolveUnit(unit string) string {
+	categories, aliases := unitCategories(), unitAliases()
+	lookup := func(name string) (string, bool) {
+		if target, ok := aliases[name]; ok {
+			return target, true
+		}
+		if _, ok := categories[name]; ok {
+			return name, true
+		}
+		return "", false
+	}
+	name := strings.ToLower(unit)
+	if out, ok := lookup(name); ok {
+		return out
+	}
+	for _, singular := range singulars(name) {
+		if out, ok := lookup(singular); ok {
+			return out
+		}
+	}
+	return unit
+}
+
+// whether the unit is in 'progress.units' or is what an alias stands for
+func isRegisteredUnit(unit string) bool {
+	name := strings.ToLower(resolveUnit(unit))
+	if _, ok := unitCategories()[name]; ok {
+		return true
+	}
+	for _, target := range unitAliases() {
+		if strings.ToLower(target) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// the category of the unit in 'progress.units'; empty if there is none
+func unitCategory(unit string) string {
+	return unitCategories()[strings.ToLower(unit)]
+}
+
+// warnings for the progress tasks of the list whose unit isn't registered;
+// there are none while neither 'progress.units' nor 'progress.aliases' has entries.
+func CheckUnits(path string) ([]string, error) {
+	path, err := prepFileTaskFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(unitCategories()) == 0 && len(unitAliases()) == 0 {
+		return nil, nil
+	}
+	var out []string
+	for _, t := range Lists[path].Tasks {
+		if t.Prog != nil && !isRegisteredUnit(t.Prog.Unit) {
+			out = append(out, fmt.Sprintf("warning: %s:%d %s has the unknown unit '%s'",
+				ListName(path), *t.ID, t.NormRegular(), t.Prog.Unit))
+		}
+	}
+	return out, nil
+}
+
+// a change of the count of a progress task; kept in the progress log of its
+// list as lines of '<time>\t<count>\t<key>\t<task>'
+type ProgressEntry struct {
+	At    time.Time
+	Count int
+	Text  string // the raw text of the task after the change
+	key   string
+}
+
+func (e *ProgressEntry) String() string {
+	return strings.Join([]string{e.At.Format(time.RFC3339), strconv.Itoa(e.Count), e.key, e.Text}, "\t")
+}
+
+func parseProgressEntry(line string) (*ProgressEntry, error) {
+	parts := strings.SplitN(line, "\t", 4)
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%w: progress entry '%s'", terrors.ErrParse, line)
+	}
+	at, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: progress entry time: %w", terrors.ErrParse, err)
+	}
+	count, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: progress entry count: %w", terrors.ErrParse, err)
+	}
+	return &ProgressEntry{At: at.Local(), Count: count, key: parts[2], Text: parts[3]}, nil
+}
+
+func progressLogPath(path string) (string, error) {
+	return etcLogPath(path, ".progress")
+}
+
+// the entries of the progress log of the list for the task with the given key,
+// or all of them if the key is empty; malformed lines are skipped
+func readProgressLog(path, key string) ([]*ProgressEntry, error) {
+	logPath, err := progressLogPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*ProgressEntry
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if entry, err := parseProgressEntry(line); err == nil && (key == "" || entry.key == key) {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func appendProgressLog(path string, entries []*ProgressEntry) error {
+	logPath, err := progressLogPath(path)
+	if err != nil {
+		return err
+	}
+	if err = mkEtcDirs(path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, entry := range entries {
+		if _, err := f.WriteString(entry.String() + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logs the change of the count of the task from prev; the first change of a
+// task is preceded by its previous count as of its creation date.
+func recordProgress(t *Task, path string, prev int) error {
+	if t.Prog.Count == prev {
+		return nil
+	}
+	key := taskKey(t)
+	history, err := readProgressLog(path, key)
+	if err != nil {
+		return err
+	}
+	var entries []*ProgressEntry
+	if len(history) == 0 {
+		at := rightNow
+		if t.Time.CreationDate != nil && t.Time.CreationDate.Before(rightNow) {
+			at = *t.Time.CreationDate
+		}
+		entries = append(entries, &ProgressEntry{At: at, Count: prev, Text: t.Raw(), key: key})
+	}
+	entries = append(entries, &ProgressEntry{At: rightNow, Count: t.Prog.Count, Text: t.Raw(), key: key})
+	return appendProgressLog(path, entries)
+}
+
+// adds the progress to the total of its unit; the order of the units is kept
+func addProgressByUnit(totals []*Progress, p *Progress) []*Progress {
+	for _, total := range totals {
+		if total.Unit == p.Unit {
+			total.Count += p.Count
+			total.DoneCount += p.DoneCount
+			return totals
+		}
+	}
+	return append(totals, &Progress{Unit: p.Unit, Count: p.Count, DoneCount: p.DoneCount})
+}
+
+// the progress of a task without one rolled up from its children; their
+// counts are summed up if they share a unit, otherwise it's the number of
+// completed children. children without a $p of their own contribute their
+// roll-up. nil if the task has a $p or none of its children has any.
+func (t *Task) AggregateProgress() *Progress {
+	if t.Prog != nil {
+		return nil
+	}
+	var parts []*Progress
+	for _, child := range t.Children {
+		if child.Prog != nil {
+			parts = append(parts, child.Prog)
+		} else if agg := child.AggregateProgress(); agg != nil {
+			parts = append(parts, agg)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	var totals []*Progress
+	for _, p := range parts {
+		totals = addProgressByUnit(totals, p)
+	}
+	if len(totals) == 1 {
+		totals[0].rolledUp = true
+		return totals[0]
+	}
+	out := &Progress{Unit: "tasks", DoneCount: len(parts), rolledUp: true}
+	for _, p := range parts {
+		if p.Count >= p.DoneCount {
+			out.Count++
+		}
+	}
+	return out
+}
+
+// the recorded progress of a task and what it projects to
+type ProgressPace struct {
+	History  []*ProgressEntry
+	PerDay   float64    // the average rate since the first entry
+	ETA      *time.Time // nil if nothing has been done yet
+	Required *float64   // the rate needed to meet the deadline while there's time left
+	OnTrack  *bool      // nil without a deadline
+}
+
+// the pace of a task from its history; counts done in less than a day are
+// taken as done in one so that a single burst doesn't project too far.
+func progressPace(t *Task, history []*ProgressEntry) *ProgressPace {
+	out := &ProgressPace{History: history}
+	remaining := t.Prog.DoneCount - t.Prog.Count
+	if len(history) > 0 {
+		days := max(rightNow.Sub(history[0].At).Hours()/24, 1)
+		out.PerDay = float64(t.Prog.Count-history[0].Count) / days
+	}
+	switch {
+	case remaining <= 0 && len(history) > 0:
+		out.ETA = utils.MkPtr(history[len(history)-1].At)
+	case remaining <= 0:
+		out.ETA = utils.MkPtr(rightNow)
+	case out.PerDay > 0:
+		eta := rightNow.Add(time.Duration(float64(remaining) / out.PerDay * 24 * float64(time.Hour)))
+		out.ETA = &eta
+	}
+	if t.Time.Deadline != nil {
+		if days := t.Time.Deadline.Sub(rightNow).Hours() / 24; days > 0 && remaining > 0 {
+			out.Required = utils.MkPtr(float64(remaining) / days)
+		}
+		out.OnTrack = utils.MkPtr(out.ETA != nil && !out.ETA.After(*t.Time.Deadline))
+	}
+	return out
+}
+
+// the task with the given id and its pace
+func TaskProgress(id int, path string) (*Task, *ProgressPace, error) {
+	path, err := prepFileTaskFromPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := getTaskFromId(id, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t.Prog == nil {
+		return nil, nil, fmt.Errorf("%w: task with id '%d' has no progress", terrors.ErrValue, id)
+	}
+	history, err := readProgressLog(path, taskKey(t))
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, progressPace(t, history), nil
+}
+
+func formatRate(rate float64, unit string) string {
+	return strconv.FormatFloat(rate, 'f', 1, 64) + " " + unit
+}
+
+// the history of the progress task followed by its rate and projection
+func RenderProgress(id int, path string) ([]string, error) {
+	t, pace, err := TaskProgress(id, path)
+	if err != nil {
+		return nil, err
+	}
+	header := "> progress | " + t.NormRegular() + " "
+	out := []string{colorize("print.color-header", header+strings.Repeat("—", max(60-utils.RuneCount(header), 0)))}
+	countLen := len(strconv.Itoa(t.Prog.DoneCount))
+	prev := -1
+	for _, entry := range pace.History {
+		line := colorize("print.color-index", formatClockDatetime(entry.At)+"  ") +
+			colorize("print.color-default", fmt.Sprintf("%*d/%d", countLen, entry.Count, t.Prog.DoneCount))
+		if prev >= 0 {
+			line += colorize("print.color-hidden", fmt.Sprintf(" (%+d)", entry.Count-prev))
+		}
+		out = append(out, line)
+		prev = entry.Count
+	}
+	if len(pace.History) == 0 {
+		out = append(out, colorize("print.color-hidden", "no recorded progress"))
+	}
+	unit := t.Prog.Unit
+	out = append(out, colorize("print.color-index", "rate      ")+
+		colorize("print.color-default", formatRate(pace.PerDay, unit)+"/day  "+formatRate(pace.PerDay*7, unit)+"/week"))
+	eta := "-"
+	if pace.ETA != nil {
+		eta = pace.ETA.In(LocalZone()).Format("2006-01-02")
+	}
+	out = append(out, colorize("print.color-index", "eta       ")+colorize("print.color-default", eta))
+	if t.Time.Deadline != nil {
+		line := colorize("print.color-index", "deadline  ") +
+			colorize("print.color-date-dead", t.Time.Deadline.In(LocalZone()).Format("2006-01-02"))
+		if pace.Required != nil {
+			line += colorize("print.color-default", ", needs "+formatRate(*pace.Required, unit)+"/day")
+		}
+		if *pace.OnTrack {
+			line += colorize("print.color-running-event", " — on track")
+		} else {
+			line += colorize("print.color-overdue", " — behind")
+		}
+		out = append(out, line)
+	}
+	return out, nil
+}
+
+func PrintProgress(id int, path string) error {
+	lines, err := RenderProgress(id, path)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
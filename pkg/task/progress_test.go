@@ -0,0 +1,204 @@
+package task
+
+import (
+	"dotxt/config"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressHistory(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 1, day, hour, 0, 0, 0, time.Local)
+	}
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+	defer func(c bool) { config.Color = c }(config.Color)
+	config.Color = false
+
+	path, _ := parseFilepath("progress")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("read $p=page/10/100 $c=2025-01-10 $due=2025-01-11 $dead=2025-01-30", path))
+	require.NoError(t, AddTaskFromStr("run $p=km/0/50 $c=2025-01-10", path))
+
+	rightNow = at(12, 20)
+	require.NoError(t, IncrementProgressCount(0, path, 10))
+	rightNow = at(14, 20)
+	require.NoError(t, SetProgressCount(0, path, 40))
+	require.NoError(t, SetProgressCount(0, path, 40)) // unchanged, not logged
+	require.NoError(t, IncrementProgressCount(1, path, 5))
+
+	history, err := readProgressLog(path, taskKey(Lists[path].Tasks[0]))
+	require.NoError(t, err)
+	var counts []int
+	for _, entry := range history {
+		counts = append(counts, entry.Count)
+	}
+	assert.Equal([]int{10, 20, 40}, counts)
+	assert.Equal(at(10, 0), history[0].At) // the baseline as of creation
+
+	rightNow = at(20, 0)
+	_, pace, err := TaskProgress(0, path)
+	require.NoError(t, err)
+	assert.InDelta(3.0, pace.PerDay, 0.001) // 30 in 10 days
+	require.NotNil(t, pace.ETA)
+	assert.Equal(at(40, 0), *pace.ETA)
+	require.NotNil(t, pace.Required)
+	assert.InDelta(6.0, *pace.Required, 0.001) // 60 left in 10 days
+	assert.False(*pace.OnTrack)
+
+	lines, err := RenderProgress(0, path)
+	require.NoError(t, err)
+	text := strings.Join(lines, "\n")
+	assert.Contains(text, "2025-01-14T20:00   40/100 (+20)")
+	assert.Contains(text, "rate      3.0 page/day  21.0 page/week")
+	assert.Contains(text, "eta       2025-02-09")
+	assert.Contains(text, "deadline  2025-01-30, needs 6.0 page/day — behind")
+
+	_, pace, err = TaskProgress(1, path)
+	require.NoError(t, err)
+	assert.Nil(pace.OnTrack)
+	assert.Len(pace.History, 2)
+
+	_, _, err = TaskProgress(0, "missing")
+	assert.Error(err)
+}
+
+func TestAggregateProgress(t *testing.T) {
+	assert := assert.New(t)
+	defer func(c bool) { config.Color = c }(config.Color)
+	config.Color = false
+
+	path, _ := parseFilepath("aggregate")
+	Lists.Empty(path)
+	for _, line := range []string{
+		"books $id=b",
+		"first $P=b $p=page/50/100/read",
+		"second $P=b $p=page/30/200/read",
+		"course $id=c",
+		"videos $P=c $p=video/10/10",
+		"exercises $P=c $p=ex/4/20",
+		"part $P=c $id=p",
+		"chapter $P=p $p=page/5/5",
+		"own $id=o $p=step/1/3",
+		"inner $P=o $p=step/2/2",
+		"plain $id=n",
+		"child $P=n",
+		"novel $p=page/20/100/read",
+		"essay $p=page/10/50/read",
+	} {
+		require.NoError(t, AddTaskFromStr(line, path))
+	}
+	tasks := Lists[path].Tasks
+
+	agg := tasks[0].AggregateProgress()
+	require.NotNil(t, agg)
+	assert.Equal(Progress{Unit: "page", Count: 80, DoneCount: 300, rolledUp: true}, *agg)
+	assert.Equal(50, tasks[1].Prog.Count) // the children are left alone
+
+	agg = tasks[3].AggregateProgress() // mixed units; the nested part counts as one
+	require.NotNil(t, agg)
+	assert.Equal(Progress{Unit: "tasks", Count: 2, DoneCount: 3, rolledUp: true}, *agg)
+
+	assert.Nil(tasks[8].AggregateProgress()) // it has its own
+	assert.Nil(tasks[10].AggregateProgress())
+	assert.Nil(tasks[11].AggregateProgress())
+
+	rtask := tasks[0].Render()
+	assert.Contains(rtask.stringify(false, -1), "80/300( 26%)")
+	assert.Contains(rtask.stringify(false, -1), "(Σpage) books")
+
+	lines, err := formatLists([]string{path}, 120, 40)
+	require.NoError(t, err)
+	var text []string
+	for _, line := range lines[path] {
+		text = append(text, line.Text)
+	}
+	assert.Contains(strings.Join(text, "\n"), "read 30/150 page (20%) —")
+}
+
+func TestUnitRegistry(t *testing.T) {
+	assert := assert.New(t)
+	for _, key := range []string{"progress.units", "progress.aliases"} {
+		defer viper.Set(key, viper.Get(key))
+	}
+	viper.Set("progress.units", map[string]any{"page": "books", "entry": "journal", "km": ""})
+	viper.Set("progress.aliases", map[string]any{"pg": "page", "kilometer": "km"})
+
+	for unit, expected := range map[string]string{
+		"page":       "page",
+		"Pages":      "page",
+		"pg":         "page",
+		"pgs":        "page",
+		"entries":    "entry",
+		"kilometers": "km",
+		"chapter":    "chapter",
+		"chapters":   "chapters",
+	} {
+		assert.Equal(expected, resolveUnit(unit), unit)
+	}
+
+	t.Run("parse", func(t *testing.T) {
+		for line, expected := range map[string]Progress{
+			"read $p=pgs/1/10":           {Unit: "page", Category: "books", Count: 1, DoneCount: 10},
+			"read $p=page/1/10/novels":   {Unit: "page", Category: "novels", Count: 1, DoneCount: 10},
+			"run $p=kilometers/2/5":      {Unit: "km", Count: 2, DoneCount: 5},
+			"write $p=chapters/1/3/book": {Unit: "chapters", Category: "book", Count: 1, DoneCount: 3},
+		} {
+			task, err := ParseTask(nil, line)
+			require.NoError(t, err)
+			require.NotNil(t, task.Prog, line)
+			assert.Equal(expected, *task.Prog, line)
+		}
+	})
+	t.Run("check", func(t *testing.T) {
+		path, _ := parseFilepath("units")
+		Lists.Empty(path)
+		require.NoError(t, AddTaskFromStr("read $p=pg/1/10", path))
+		require.NoError(t, AddTaskFromStr("write $p=chapters/1/3", path))
+		require.NoError(t, AddTaskFromStr("plain", path))
+		warnings, err := CheckUnits(path)
+		require.NoError(t, err)
+		assert.Equal([]string{"warning: units:1 write has the unknown unit 'chapters'"}, warnings)
+
+		viper.Set("progress.units", map[string]any{})
+		viper.Set("progress.aliases", map[string]any{})
+		warnings, err = CheckUnits(path)
+		require.NoError(t, err)
+		assert.Empty(warnings)
+	})
+	t.Run("store", func(t *testing.T) {
+		prevConfig := config.ConfigPath()
+		defer config.SelectConfigFile(prevConfig)
+		tmpDir, err := os.MkdirTemp(prevConfig, "")
+		require.Nil(t, err)
+		config.SelectConfigFile(tmpDir)
+		viper.Set("progress.units", map[string]any{"page": "books"})
+
+		path, _ := parseFilepath("units")
+		Lists.Empty(path)
+		require.NoError(t, AddTaskFromStr("read $p=Pages/3/10 $c=2025-01-10", path))
+		require.NoError(t, AddTaskFromStr("unrelated $c=2025-01-10", path))
+		require.NoError(t, StoreFile(path)) // the text stays as written
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal("read $p=Pages/3/10 $c=2025-01-10\nunrelated $c=2025-01-10", string(data))
+
+		require.NoError(t, IncrementProgressCount(0, path, 1))
+		require.NoError(t, StoreFile(path))
+		data, err = os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal("read $p=page/4/10/books $c=2025-01-10\nunrelated $c=2025-01-10", string(data))
+	})
+}
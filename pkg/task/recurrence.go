@@ -0,0 +1,379 @@
+package task
+
+import (
+	"dotxt/pkg/terrors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FreqDaily    Frequency = "daily"
+	FreqWeekly   Frequency = "weekly"
+	FreqMonthly  Frequency = "monthly"
+	FreqYearly   Frequency = "yearly"
+	FreqWeekdays Frequency = "weekdays"
+	FreqWeekends Frequency = "weekends"
+)
+
+// the n-th weekday of a month; a negative n counts from the end
+type NthWeekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// A calendar-aware recurrence rule. empty specs default to the anchor's
+// weekday, day of month or date; e.g. monthly on the anchor's day of month.
+// monthly and yearly ones are pinned to the due date of their task on parsing.
+type Recurrence struct {
+	Freq     Frequency
+	Interval int            // every n days, weeks, months or years
+	Weekdays []time.Weekday // weekly
+	MonthDay []int          // monthly; -1 is the last day
+	NthDay   []NthWeekday   // monthly
+	Month    time.Month     // yearly
+	Day      int            // yearly
+
+	pinMonth time.Month // stands in for an empty spec; see pin
+	pinDay   int
+}
+
+/*
+recurrence: <freq>[/<interval>][:<spec>]
+freq:
+
+	daily, weekly, monthly, yearly
+	weekdays, weekends: the working days of 'time.work-days' and the rest;
+	the interval counts weeks
+
+spec:
+
+	weekly:  comma separated weekdays; e.g. mon,thu
+	monthly: comma separated days of month or nth weekdays; e.g. 1,15,-1 or 2tue,-1fri
+	yearly:  <month>-<day>; e.g. 12-25 or dec-25
+*/
+func parseRecurrence(value string) (*Recurrence, error) {
+	value = strings.ToLower(value)
+	rule, spec, hasSpec := strings.Cut(value, ":")
+	freq, intervalStr, hasInterval := strings.Cut(rule, "/")
+	rec := &Recurrence{Freq: Frequency(freq), Interval: 1}
+	if hasInterval {
+		interval, err := strconv.Atoi(intervalStr)
+		if err != nil || interval < 1 {
+			return nil, fmt.Errorf("%w: %w: $rec: interval must be a positive integer not '%s'", terrors.ErrParse, terrors.ErrValue, intervalStr)
+		}
+		rec.Interval = interval
+	}
+	if hasSpec && spec == "" {
+		return nil, fmt.Errorf("%w: $rec: empty spec", terrors.ErrParse)
+	}
+	switch rec.Freq {
+	case FreqDaily, FreqWeekdays, FreqWeekends:
+		if hasSpec {
+			return nil, fmt.Errorf("%w: $rec: '%s' takes no spec", terrors.ErrParse, freq)
+		}
+	case FreqWeekly:
+		for part := range strings.SplitSeq(spec, ",") {
+			if !hasSpec {
+				break
+			}
+			wd, err := parseWeekday(part)
+			if err != nil {
+				return nil, fmt.Errorf("%w: $rec: %w", terrors.ErrParse, err)
+			}
+			if !slices.Contains(rec.Weekdays, wd) {
+				rec.Weekdays = append(rec.Weekdays, wd)
+			}
+		}
+	case FreqMonthly:
+		for part := range strings.SplitSeq(spec, ",") {
+			if !hasSpec {
+				break
+			}
+			if day, err := strconv.Atoi(part); err == nil {
+				if day == 0 || day > 31 || day < -31 {
+					return nil, fmt.Errorf("%w: %w: $rec: invalid day of month '%d'", terrors.ErrParse, terrors.ErrValue, day)
+				}
+				rec.MonthDay = append(rec.MonthDay, day)
+				continue
+			}
+			ndx := strings.IndexFunc(part, func(r rune) bool { return r >= 'a' && r <= 'z' })
+			if ndx < 1 {
+				return nil, fmt.Errorf("%w: $rec: invalid monthly spec '%s'", terrors.ErrParse, part)
+			}
+			n, err := strconv.Atoi(part[:ndx])
+			if err != nil || n == 0 || n > 5 || n < -5 {
+				return nil, fmt.Errorf("%w: %w: $rec: invalid nth weekday '%s'", terrors.ErrParse, terrors.ErrValue, part)
+			}
+			wd, err := parseWeekday(part[ndx:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: $rec: %w", terrors.ErrParse, err)
+			}
+			rec.NthDay = append(rec.NthDay, NthWeekday{N: n, Weekday: wd})
+		}
+	case FreqYearly:
+		if hasSpec {
+			monthStr, dayStr, ok := strings.Cut(spec, "-")
+			if !ok || monthStr == "" {
+				return nil, fmt.Errorf("%w: $rec: yearly spec must be <month>-<day> not '%s'", terrors.ErrParse, spec)
+			}
+			month, err := strconv.Atoi(monthStr)
+			if err != nil {
+				t, err := time.Parse("Jan", strings.ToUpper(monthStr[:1])+monthStr[1:])
+				if err != nil {
+					return nil, fmt.Errorf("%w: $rec: invalid month '%s'", terrors.ErrParse, monthStr)
+				}
+				month = int(t.Month())
+			}
+			day, err := strconv.Atoi(dayStr)
+			if err != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+				return nil, fmt.Errorf("%w: %w: $rec: invalid date '%s'", terrors.ErrParse, terrors.ErrValue, spec)
+			}
+			rec.Month, rec.Day = time.Month(month), day
+		}
+	default:
+		return nil, fmt.Errorf("%w: $rec: unknown frequency '%s'", terrors.ErrParse, freq)
+	}
+	return rec, nil
+}
+
+func (rec Recurrence) String() string {
+	out := string(rec.Freq)
+	if rec.Interval > 1 {
+		out += "/" + strconv.Itoa(rec.Interval)
+	}
+	var spec []string
+	for _, wd := range rec.Weekdays {
+		spec = append(spec, strings.ToLower(wd.String()[:3]))
+	}
+	for _, day := range rec.MonthDay {
+		spec = append(spec, strconv.Itoa(day))
+	}
+	for _, nth := range rec.NthDay {
+		spec = append(spec, strconv.Itoa(nth.N)+strings.ToLower(nth.Weekday.String()[:3]))
+	}
+	if rec.Month != 0 {
+		spec = append(spec, fmt.Sprintf("%02d-%02d", rec.Month, rec.Day))
+	}
+	if len(spec) > 0 {
+		out += ":" + strings.Join(spec, ",")
+	}
+	return out
+}
+
+// keeps the date of the anchor in place of an empty monthly or yearly spec so
+// that the series keeps to it once an occurrence is clamped to a shorter month;
+// e.g. monthly from jan 31st goes on to mar 31st rather than mar 28th.
+// the spec itself is left empty so that the rule is written as it was given.
+func (rec *Recurrence) pin(anchor time.Time) {
+	rec.pinMonth, rec.pinDay = anchor.Month(), anchor.Day()
+}
+
+// a rough length of a single period used for comparisons
+func (rec *Recurrence) approx() time.Duration {
+	const day = 24 * time.Hour
+	var period time.Duration
+	switch rec.Freq {
+	case FreqDaily:
+		period = day
+	case FreqWeekdays, FreqWeekends, FreqWeekly:
+		period = 7 * day
+	case FreqMonthly:
+		period = 30 * day
+	case FreqYearly:
+		period = 365 * day
+	}
+	return period * time.Duration(rec.Interval)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.Local).Day()
+}
+
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// whether the day is an occurrence of the series anchored at anchor
+func (rec *Recurrence) matches(day, anchor time.Time) bool {
+	interval := max(rec.Interval, 1)
+	switch rec.Freq {
+	case FreqDaily:
+		return daysBetween(anchor, day)%interval == 0
+	case FreqWeekly, FreqWeekdays, FreqWeekends:
+		weeks := daysBetween(startOfWeek(anchor), startOfWeek(day)) / 7
+		if weeks%interval != 0 {
+			return false
+		}
+		wd := day.Weekday()
+		switch rec.Freq {
+		case FreqWeekdays:
+			return workDays()[wd]
+		case FreqWeekends:
+			return !workDays()[wd]
+		}
+		if len(rec.Weekdays) == 0 {
+			return wd == anchor.Weekday()
+		}
+		return slices.Contains(rec.Weekdays, wd)
+	case FreqMonthly:
+		months := (day.Year()-anchor.Year())*12 + int(day.Month()-anchor.Month())
+		if months%interval != 0 {
+			return false
+		}
+		last := daysIn(day.Year(), day.Month())
+		matchDay := func(d int) bool {
+			if d < 0 {
+				d = max(last+d+1, 1)
+			}
+			return day.Day() == min(d, last)
+		}
+		if len(rec.MonthDay) == 0 && len(rec.NthDay) == 0 {
+			if rec.pinDay != 0 {
+				return matchDay(rec.pinDay)
+			}
+			return matchDay(anchor.Day())
+		}
+		if slices.ContainsFunc(rec.MonthDay, matchDay) {
+			return true
+		}
+		for _, nth := range rec.NthDay {
+			if day.Weekday() != nth.Weekday {
+				continue
+			}
+			if nth.N > 0 && (day.Day()-1)/7+1 == nth.N {
+				return true
+			}
+			if nth.N < 0 && (last-day.Day())/7+1 == -nth.N {
+				return true
+			}
+		}
+		return false
+	case FreqYearly:
+		if (day.Year()-anchor.Year())%interval != 0 {
+			return false
+		}
+		month, d := rec.Month, rec.Day
+		if month == 0 && rec.pinMonth != 0 {
+			month, d = rec.pinMonth, rec.pinDay
+		} else if month == 0 {
+			month, d = anchor.Month(), anchor.Day()
+		}
+		return day.Month() == month && day.Day() == min(d, daysIn(day.Year(), month))
+	}
+	return false
+}
+
+// the first occurrence strictly after the given time of the series anchored at anchor.
+// occurrences take the time of day of the anchor in the anchor's zone.
+func (rec *Recurrence) Next(after, anchor time.Time) (time.Time, bool) {
+	loc := anchor.Location()
+	day := after.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	limit := 366 * 8 * max(rec.Interval, 1) // enough for a feb 29th every n years
+	for range limit {
+		if rec.matches(day, anchor) {
+			out := time.Date(day.Year(), day.Month(), day.Day(),
+				anchor.Hour(), anchor.Minute(), anchor.Second(), 0, loc)
+			if out.After(after) {
+				return out, true
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
+// the first occurrence of the task's series strictly after the given time;
+// the series is anchored at due; false if the task doesn't recur.
+// occurrences falling on holidays are skipped.
+func (t *Temporal) nextOccurrence(due, after time.Time) (time.Time, bool) {
+	next, ok := t.nextRawOccurrence(due, after)
+	for tries := 0; ok && isHoliday(next) && tries < 366; tries++ {
+		next, ok = t.nextRawOccurrence(due, next)
+	}
+	return next, ok
+}
+
+func (t *Temporal) nextRawOccurrence(due, after time.Time) (time.Time, bool) {
+	if t.Recur != nil {
+		return t.Recur.Next(after, due)
+	}
+	if t.Every != nil && *t.Every > 0 {
+		next := due
+		if after.After(due) { // skips the whole periods in between
+			next = due.Add(after.Sub(due) / *t.Every * *t.Every)
+		}
+		for !next.After(after) {
+			next = next.Add(*t.Every)
+		}
+		return next, true
+	}
+	return time.Time{}, false
+}
+
+// the first occurrence after the given time within the bounds of the series
+// along with the number of occurrences passed to get there; false once the
+// series is exhausted or if the task doesn't recur.
+func (t *Temporal) nextBoundedOccurrence(due, after time.Time) (time.Time, int, bool) {
+	next, passed, ok := due, 0, true
+	if t.Times == nil {
+		next, ok = t.nextOccurrence(due, after)
+		passed = 1
+	} else {
+		for ok && (passed == 0 || !next.After(after)) {
+			next, ok = t.nextOccurrence(due, next)
+			passed++
+		}
+	}
+	if !ok || (t.Until != nil && next.After(*t.Until)) ||
+		(t.Times != nil && passed >= *t.Times) {
+		return time.Time{}, 0, false
+	}
+	return next, passed, true
+}
+
+func (t *Task) recurs() bool {
+	return t.Time != nil && t.Time.DueDate != nil &&
+		(t.Time.Every != nil || t.Time.Recur != nil)
+}
+
+// takes n occurrences off of $times
+func (t *Task) consumeTimes(n int) {
+	if t.Time.Times == nil {
+		return
+	}
+	*t.Time.Times -= n
+	if tk, _ := t.Tokens.Find(TkByTypeKey(TokenRecurrence, "times")); tk != nil {
+		*tk.raw = tk.String()
+	}
+}
+
+// moves a recurring task on to its next occurrence, returning the text of
+// the instance being completed; false if the task doesn't recur or if its
+// series is exhausted.
+func (t *Task) advance() (string, bool) {
+	if !t.recurs() {
+		return "", false
+	}
+	due := *t.Time.DueDate
+	after := rightNow
+	if due.After(after) {
+		after = due
+	}
+	next, passed, ok := t.Time.nextBoundedOccurrence(due, after)
+	if !ok {
+		return "", false
+	}
+	instance := &Task{Tokens: *t.Tokens.Filter(TkByTypeKey(TokenFormat, "focus").Not())}
+	out := instance.Raw()
+	t.consumeTimes(passed)
+	t.shiftDates(next.Sub(due))
+	return out, true
+}
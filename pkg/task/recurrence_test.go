@@ -0,0 +1,282 @@
+package task
+
+import (
+	"dotxt/config"
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	assert := assert.New(t)
+	helper := func(value string) *Recurrence {
+		rec, err := parseRecurrence(value)
+		require.NoError(t, err, value)
+		return rec
+	}
+	assert.Equal(&Recurrence{Freq: FreqDaily, Interval: 1}, helper("daily"))
+	assert.Equal(&Recurrence{Freq: FreqWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Thursday}}, helper("weekly/2:mon,thursday"))
+	assert.Equal(&Recurrence{Freq: FreqMonthly, Interval: 1, MonthDay: []int{1, -1}, NthDay: []NthWeekday{{2, time.Tuesday}, {-1, time.Friday}}}, helper("monthly:1,2tue,-1,-1fri"))
+	assert.Equal(&Recurrence{Freq: FreqYearly, Interval: 1, Month: time.December, Day: 25}, helper("yearly:dec-25"))
+	assert.Equal(&Recurrence{Freq: FreqYearly, Interval: 1, Month: time.March, Day: 5}, helper("yearly:3-5"))
+
+	for value, expected := range map[string]string{
+		"daily":              "daily",
+		"weekdays/2":         "weekdays/2",
+		"weekly/1:mon,Wed":   "weekly:mon,wed",
+		"monthly:15,-1fri":   "monthly:15,-1fri",
+		"yearly/3:dec-25":    "yearly/3:12-25",
+		"monthly/2:1,1st,-1": "",
+		"weekly:someday":     "",
+		"hourly":             "",
+		"daily:mon":          "",
+		"daily/0":            "",
+		"monthly:32":         "",
+		"monthly:6tue":       "",
+		"yearly:13-1":        "",
+		"yearly:dec":         "",
+		"yearly:-25":         "",
+		"weekly:":            "",
+	} {
+		rec, err := parseRecurrence(value)
+		if expected == "" {
+			assert.ErrorIs(err, terrors.ErrParse, value)
+			continue
+		}
+		if assert.NoError(err, value) {
+			assert.Equal(expected, rec.String(), value)
+		}
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	assert := assert.New(t)
+	defer viper.Set("time.week-start", viper.GetString("time.week-start"))
+	viper.Set("time.week-start", "mon")
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.Local)
+	}
+	helper := func(value string, after, anchor time.Time) time.Time {
+		rec, err := parseRecurrence(value)
+		require.NoError(t, err, value)
+		next, ok := rec.Next(after, anchor)
+		assert.True(ok, value)
+		return next
+	}
+	anchor := date(2025, 1, 15, 9) // wednesday
+	assert.Equal(date(2025, 1, 16, 9), helper("daily", anchor, anchor))
+	assert.Equal(date(2025, 1, 18, 9), helper("daily/3", anchor, anchor))
+	assert.Equal(date(2025, 1, 15, 9), helper("daily", date(2025, 1, 15, 8), anchor))
+	assert.Equal(date(2025, 1, 22, 9), helper("weekly", anchor, anchor))
+	assert.Equal(date(2025, 1, 17, 9), helper("weekly:mon,fri", anchor, anchor))
+	assert.Equal(date(2025, 1, 27, 9), helper("weekly/2:mon,fri", date(2025, 1, 17, 9), anchor))
+	assert.Equal(date(2025, 1, 16, 9), helper("weekdays", anchor, anchor))
+	assert.Equal(date(2025, 1, 20, 9), helper("weekdays", date(2025, 1, 17, 10), anchor))
+	assert.Equal(date(2025, 1, 18, 9), helper("weekends", anchor, anchor))
+	assert.Equal(date(2025, 2, 15, 9), helper("monthly", anchor, anchor))
+	assert.Equal(date(2025, 3, 15, 9), helper("monthly/2", anchor, anchor))
+	assert.Equal(date(2025, 1, 31, 9), helper("monthly:-1", anchor, anchor))
+	assert.Equal(date(2025, 2, 28, 9), helper("monthly:31", date(2025, 1, 31, 10), anchor))
+	assert.Equal(date(2025, 1, 31, 9), helper("monthly:-1fri", anchor, anchor))
+	assert.Equal(date(2025, 2, 28, 9), helper("monthly:-1fri", date(2025, 1, 31, 10), anchor))
+	assert.Equal(date(2025, 2, 11, 9), helper("monthly:2tue", anchor, anchor))
+	assert.Equal(date(2026, 1, 15, 9), helper("yearly", anchor, anchor))
+	assert.Equal(date(2025, 12, 25, 9), helper("yearly:dec-25", anchor, anchor))
+	leap := date(2024, 2, 29, 0)
+	assert.Equal(date(2025, 2, 28, 0), helper("yearly", leap, leap))
+}
+
+func TestRecurrenceToken(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	task, err := ParseTask(nil, "pay rent $c=2025-01-15T09 $rec=monthly:1")
+	require.NoError(t, err)
+	require.NotNil(t, task.Time.Recur)
+	assert.Equal(time.Date(2025, 2, 1, 9, 0, 0, 0, time.Local), *task.Time.DueDate)
+	assert.Equal("pay rent $rec=monthly:1 $due=2025-02T09", task.Norm())
+
+	task, err = ParseTask(nil, "both $every=1w $rec=weekly")
+	require.NoError(t, err)
+	assert.Nil(task.Time.Every)
+	assert.NotNil(task.Time.Recur)
+
+	task, err = ParseTask(nil, "bad $rec=hourly")
+	require.NoError(t, err)
+	assert.Nil(task.Time.Recur)
+}
+
+func TestRecurrencePin(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 1, 9, 0, 0, 0, time.Local)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.Local)
+	}
+	dues := func(line string, n int) []time.Time {
+		task, err := ParseTask(nil, line)
+		require.NoError(t, err)
+		var out []time.Time
+		for range n {
+			_, ok := task.advance()
+			require.True(t, ok)
+			out = append(out, *task.Time.DueDate)
+		}
+		return out
+	}
+
+	task, err := ParseTask(nil, "rent $c=2025-01-01 $due=2025-01-31T09 $rec=monthly")
+	require.NoError(t, err)
+	for range 2 {
+		_, ok := task.advance()
+		require.True(t, ok)
+	}
+	// the pinned day is kept apart from the rule as written
+	assert.Equal("rent $due=2025-03-31T09 $rec=monthly", task.Norm())
+	task, err = ParseTask(nil, "rent $c=2025-01-01 $due=2025-01-31T09 $rec=Monthly/1")
+	require.NoError(t, err)
+	assert.Equal("rent $due=2025-01-31T09 $rec=Monthly/1", task.Norm())
+	assert.Equal([]time.Time{
+		date(2025, 2, 28), date(2025, 3, 31), date(2025, 4, 30), date(2025, 5, 31),
+	}, dues("rent $c=2025-01-01 $due=2025-01-31T09 $rec=monthly", 4))
+	assert.Equal([]time.Time{
+		date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28), date(2028, 2, 29),
+	}, dues("leap $c=2024-01-01 $due=2024-02-29T09 $rec=yearly", 4))
+	assert.Equal([]time.Time{date(2025, 2, 11), date(2025, 3, 11)},
+		dues("nth $c=2025-01-01 $due=2025-01-14T09 $rec=monthly:2tue", 2))
+}
+
+func TestCheckAndRecurCalendar(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	path, _ := parseFilepath("recurrence")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("report $c=2025-01-01 $due=2025-01-31T17 $rec=monthly:-1fri", path))
+	require.NoError(t, AddTaskFromStr("water $c=2025-03-02 $due=2025-03-03T09 $every=1w $t=2025-03-02T09 $dead=due:1d", path))
+	require.NoError(t, CheckAndRecurTasks(path))
+	assert.Equal(time.Date(2025, 3, 28, 17, 0, 0, 0, time.Local), *Lists[path].Tasks[0].Time.DueDate)
+
+	task := Lists[path].Tasks[1] // every date moves along with the due, as on done
+	assert.Equal(time.Date(2025, 3, 17, 9, 0, 0, 0, time.Local), *task.Time.DueDate)
+	assert.Equal(time.Date(2025, 3, 16, 9, 0, 0, 0, time.Local), *task.Time.Threshold)
+	assert.Equal(time.Date(2025, 3, 18, 9, 0, 0, 0, time.Local), *task.Time.Deadline)
+	assert.Equal("water $c=2025-03-02 $due=2025-03-17T09 $every=1w $t=2025-03-16T09 $dead=due:1d", task.Raw())
+}
+
+func TestRecurrenceBounds(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	t.Run("parse", func(t *testing.T) {
+		task, err := ParseTask(nil, "standup $c=2025-01-15T09 $due=2025-01-16T09 $every=1d $until=due:2d $times=5")
+		require.NoError(t, err)
+		if assert.NotNil(task.Time.Until) {
+			assert.Equal(time.Date(2025, 1, 18, 9, 0, 0, 0, time.Local), *task.Time.Until)
+		}
+		if assert.NotNil(task.Time.Times) {
+			assert.Equal(5, *task.Time.Times)
+		}
+		tk, _ := task.Tokens.Find(TkByTypeKey(TokenDate, "until"))
+		require.NotNil(t, tk)
+		assert.Equal("", tk.Value.(*TokenDateValue).RelKey)
+		assert.Equal("$until=2025-01-18T09", *tk.raw)
+
+		task, err = ParseTask(nil, "plain $until=1w $times=3")
+		require.NoError(t, err)
+		assert.Nil(task.Time.Until)
+		assert.Nil(task.Time.Times)
+		assert.Equal("plain $until=1w $times=3", task.Norm())
+
+		task, err = ParseTask(nil, "bad $every=1d $times=0")
+		require.NoError(t, err)
+		assert.Nil(task.Time.Times)
+	})
+	t.Run("next", func(t *testing.T) {
+		due := time.Date(2025, 1, 16, 9, 0, 0, 0, time.Local)
+		tm := &Temporal{
+			Every: utils.MkPtr(24 * time.Hour),
+			Until: utils.MkPtr(time.Date(2025, 1, 18, 9, 0, 0, 0, time.Local)),
+		}
+		next, passed, ok := tm.nextBoundedOccurrence(due, due)
+		assert.True(ok)
+		assert.Equal(1, passed)
+		assert.Equal(due.AddDate(0, 0, 1), next)
+		_, _, ok = tm.nextBoundedOccurrence(due, due.AddDate(0, 0, 2))
+		assert.False(ok)
+
+		tm = &Temporal{Every: utils.MkPtr(24 * time.Hour), Times: utils.MkPtr(3)}
+		_, _, ok = tm.nextBoundedOccurrence(due, due.AddDate(0, 0, 2).Add(time.Hour))
+		assert.False(ok)
+		*tm.Times = 4
+		next, passed, ok = tm.nextBoundedOccurrence(due, due.AddDate(0, 0, 2).Add(time.Hour))
+		assert.True(ok)
+		assert.Equal(3, passed)
+		assert.Equal(due.AddDate(0, 0, 3), next)
+	})
+	t.Run("render", func(t *testing.T) {
+		id := 0
+		task, err := ParseTask(&id, "standup $c=2025-01-15T09 $due=2025-01-16T09 $every=1d $until=2025-01-18T09 $times=5")
+		require.NoError(t, err)
+		var raws []string
+		for _, tk := range task.Render().tokens {
+			raws = append(raws, tk.raw)
+		}
+		assert.Contains(raws, "$every=1d,until=3d,times=5")
+		assert.NotContains(raws, "$times=5")
+	})
+}
+
+func TestRecurrenceExhaustion(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	path, _ := parseFilepath("bounded")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("a $c=2025-01-15T09 $due=2025-01-16T09 $every=1d $times=2", path))
+	require.NoError(t, AddTaskFromStr("b $c=2025-01-01T09 $due=2025-01-02T09 $every=1d $until=2025-01-10", path))
+	require.NoError(t, AddTaskFromStr("c $c=2025-01-01T09 $due=2025-01-10T09 $every=1w $times=3", path))
+
+	require.NoError(t, DoneTask([]int{0}, path))
+	task := Lists[path].Tasks[0]
+	assert.Equal(time.Date(2025, 1, 17, 9, 0, 0, 0, time.Local), *task.Time.DueDate)
+	assert.Equal(1, *task.Time.Times)
+	tk, _ := task.Tokens.Find(TkByTypeKey(TokenRecurrence, "times"))
+	require.NotNil(t, tk)
+	assert.Equal("$times=1", *tk.raw)
+
+	require.NoError(t, DoneTask([]int{0}, path))
+	require.Equal(t, 2, Lists.Len(path))
+
+	require.NoError(t, CheckAndRecurTasks(path))
+	require.Equal(t, 1, Lists.Len(path))
+	task = Lists[path].Tasks[0]
+	assert.Equal(time.Date(2025, 1, 17, 9, 0, 0, 0, time.Local), *task.Time.DueDate)
+	assert.Equal(2, *task.Time.Times)
+
+	raw, err := os.ReadFile(filepath.Join(todosDir(), "_etc", "bounded.done"))
+	require.NoError(t, err)
+	lines := strings.Split(string(raw), "\n")
+	require.Len(t, lines, 3)
+	assert.True(strings.HasPrefix(lines[0], "a $c=2025-01-15T09 $due=2025-01-16T09 $every=1d $times=2"))
+	assert.True(strings.HasPrefix(lines[1], "a $c=2025-01-15T09"))
+	assert.Contains(lines[1], "$times=1")
+	assert.True(strings.HasPrefix(lines[2], "b "))
+}
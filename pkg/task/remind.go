@@ -0,0 +1,126 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// a reminder whose time has passed
+type Reminder struct {
+	List string // name of the list
+	ID   int
+	At   time.Time
+	Task *Task
+	key  string
+}
+
+// the fired reminders are kept here as lines of '<list>\t<key>'
+func firedRemindersPath() string {
+	return filepath.Join(etcDir(), ".fired-reminders")
+}
+
+// identifies a reminder across restarts; the key of its task at the time it fires
+func reminderKey(t *Task, at time.Time) string {
+	return fmt.Sprintf("%s-%d", taskKey(t), at.Unix())
+}
+
+func readFiredReminders() (map[string]bool, error) {
+	out := make(map[string]bool)
+	data, err := os.ReadFile(firedRemindersPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return out, err
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			out[line] = true
+		}
+	}
+	return out, nil
+}
+
+func writeFiredReminders(fired map[string]bool) error {
+	var lines []string
+	for line := range fired {
+		lines = append(lines, line)
+	}
+	slices.Sort(lines)
+	return os.WriteFile(firedRemindersPath(), []byte(strings.Join(lines, "\n")), 0o644)
+}
+
+// the passed reminders of the given lists which are yet to be fired, oldest first;
+// also returns the records of the fired ones that are still found in the lists
+func pendingReminders(paths []string, fired map[string]bool) ([]*Reminder, map[string]bool, error) {
+	var out []*Reminder
+	found := make(map[string]bool)
+	for _, path := range paths {
+		path, err := prepFileTaskFromPath(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		list := ListName(path)
+		for _, t := range Lists[path].Tasks {
+			for _, at := range t.Time.Reminders {
+				if at.After(rightNow) {
+					continue
+				}
+				key := list + "\t" + reminderKey(t, *at)
+				if fired[key] {
+					found[key] = true
+					continue
+				}
+				out = append(out, &Reminder{List: list, ID: *t.ID, At: *at, Task: t, key: key})
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(l, r *Reminder) int {
+		return l.At.Compare(r.At)
+	})
+	return out, found, nil
+}
+
+// calls fire upon the pending reminders of the given lists and records them
+// so that each fires only once. records of reminders that are gone from
+// these lists are dropped. it stops at the first error of fire.
+func FireReminders(paths []string, fire func(*Reminder) error) error {
+	if err := mkDirs(""); err != nil {
+		return err
+	}
+	fired, err := readFiredReminders()
+	if err != nil {
+		return err
+	}
+	pending, keep, err := pendingReminders(paths, fired)
+	if err != nil {
+		return err
+	}
+	scanned := make(map[string]bool)
+	for _, path := range paths {
+		if path, err := prepFileTaskFromPath(path); err == nil {
+			scanned[ListName(path)] = true
+		}
+	}
+	for key := range fired {
+		list, _, _ := strings.Cut(key, "\t")
+		if !scanned[list] {
+			keep[key] = true
+		}
+	}
+	var fireErr error
+	for _, r := range pending {
+		if fireErr = fire(r); fireErr != nil {
+			break
+		}
+		keep[r.key] = true
+	}
+	if err := writeFiredReminders(keep); err != nil {
+		return err
+	}
+	return fireErr
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"dotxt/config"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFireReminders(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	path, _ := parseFilepath("reminders")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("a $c=2025-01-10T09 $due=2025-01-20T09 $r=2025-01-14T09 $r=2025-01-16T09", path))
+	require.NoError(t, AddTaskFromStr("b $c=2025-01-10T09 $due=2025-01-20T09 $r=2025-01-12T09", path))
+	require.NoError(t, AddTaskFromStr("c $c=2025-01-10T09 $due=2025-01-20T09", path))
+
+	var fired []*Reminder
+	collect := func(r *Reminder) error {
+		fired = append(fired, r)
+		return nil
+	}
+	require.NoError(t, FireReminders([]string{path}, collect))
+	require.Len(t, fired, 2)
+	assert.Equal("b", fired[0].Task.NormRegular())
+	assert.Equal("reminders", fired[0].List)
+	assert.Equal(time.Date(2025, 1, 12, 9, 0, 0, 0, time.Local), fired[0].At)
+	assert.Equal("a", fired[1].Task.NormRegular())
+
+	t.Run("once", func(t *testing.T) {
+		fired = nil
+		require.NoError(t, FireReminders([]string{path}, collect))
+		assert.Empty(fired)
+	})
+	t.Run("later", func(t *testing.T) {
+		fired = nil
+		rightNow = time.Date(2025, 1, 16, 10, 0, 0, 0, time.Local)
+		require.NoError(t, ReplaceTask(0, "a $c=2025-01-10T09 $due=2025-01-21T09 $r=2025-01-14T09 $r=2025-01-16T09 +edited", path))
+		require.NoError(t, FireReminders([]string{path}, collect))
+		require.Len(t, fired, 1)
+		assert.Equal(time.Date(2025, 1, 16, 9, 0, 0, 0, time.Local), fired[0].At)
+	})
+	t.Run("error", func(t *testing.T) {
+		rightNow = time.Date(2025, 1, 17, 10, 0, 0, 0, time.Local)
+		require.NoError(t, AddTaskFromStr("d $c=2025-01-10T09 $due=2025-01-20T09 $r=2025-01-17T09", path))
+		failure := errors.New("failure")
+		err := FireReminders([]string{path}, func(r *Reminder) error { return failure })
+		assert.ErrorIs(err, failure)
+		fired = nil
+		require.NoError(t, FireReminders([]string{path}, collect))
+		require.Len(t, fired, 1)
+		assert.Equal("d", fired[0].Task.NormRegular())
+	})
+}
@@ -74,11 +74,12 @@ func sortTime(lv, rv *time.Time) int {
 	return 2
 }
 
-func sortUrgentTime(lv, rv *time.Time) int {
+// lw and rw are the urgency windows of the tasks of the dates
+func sortUrgentTime(lv, rv *time.Time, lw, rw time.Duration) int {
 	if lv == nil && rv == nil {
 		return 2
 	}
-	lvu, rvu := IsDateUrgent(lv), IsDateUrgent(rv)
+	lvu, rvu := dateUrgency(lv, lw) != UrgencyNone, dateUrgency(rv, rw) != UrgencyNone
 	if lvu && !rvu {
 		return -1
 	} else if !lvu && rvu {
@@ -105,6 +106,12 @@ func sortUrgency(l, r *Task) int {
 		}
 	}
 
+	if lu, ru := l.Urgency(), r.Urgency(); lu > ru {
+		return -1
+	} else if lu < ru {
+		return 1
+	}
+
 	// TODO: (sort) in the case that DueDate is urgent and has not passed,
 	//  DueDate takes precedence over End and Deadline
 	//  only in the case that DueDate has been passed, End and Dead matter
@@ -123,9 +130,10 @@ func sortUrgency(l, r *Task) int {
 		earliest(l.Time.EndDate, l.Time.Deadline),
 		earliest(r.Time.EndDate, r.Time.Deadline)
 
-	if v := sortUrgentTime(lED, rED); v != 2 {
+	lw, rw := l.urgencyWindow(), r.urgencyWindow()
+	if v := sortUrgentTime(lED, rED, lw, rw); v != 2 {
 		return v
-	} else if v := sortUrgentTime(l.Time.DueDate, r.Time.DueDate); v != 2 {
+	} else if v := sortUrgentTime(l.Time.DueDate, r.Time.DueDate, lw, rw); v != 2 {
 		return v
 	}
 	if l.Urgent == r.Urgent {
@@ -309,6 +317,16 @@ func sortChildren(l, r *Task) int {
 	return 2
 }
 
+func sortRecurrence(lv, rv *Recurrence) int {
+	if v := sortNil(lv, rv); v != 3 {
+		return v
+	}
+	if v := sortDuration(utils.MkPtr(lv.approx()), utils.MkPtr(rv.approx())); v != 2 {
+		return v
+	}
+	return sortString(lv.String(), rv.String())
+}
+
 func sortHelper(l, r *Task) int {
 	if v := sortNil(l, r); v != 3 {
 		if v == 2 {
@@ -333,6 +351,8 @@ func sortHelper(l, r *Task) int {
 		return v
 	} else if v = sortDuration(l.Time.Every, r.Time.Every); v != 2 {
 		return v
+	} else if v = sortRecurrence(l.Time.Recur, r.Time.Recur); v != 2 {
+		return v
 	} else if v = sortID(l.EID, r.EID); v != 2 {
 		return v
 	} else if v = sortID(l.PID, r.PID); v != 2 {
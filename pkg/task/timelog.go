@@ -0,0 +1,335 @@
+package task
+
+import (
+	"cmp"
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// a span of time tracked for a task; kept in the time log of its list
+// as lines of '<start>\t<stop>\t<key>\t<task>' where stop is empty while running
+type ClockEntry struct {
+	Start time.Time
+	Stop  *time.Time
+	Text  string // the raw text of the task at clock-in
+	key   string
+}
+
+// identifies a task across restarts in the logs of its list; it survives
+// changes to the task's dates and hints but not to its regular text.
+func taskKey(t *Task) string {
+	h := fnv.New64a()
+	h.Write([]byte(t.NormRegular()))
+	var c int64
+	if t.Time.CreationDate != nil {
+		c = t.Time.CreationDate.Unix()
+	}
+	return fmt.Sprintf("%d-%x", c, h.Sum64())
+}
+
+// the span of the entry; running ones are counted up to now
+func (e *ClockEntry) duration() time.Duration {
+	if e.Stop == nil {
+		return rightNow.Sub(e.Start)
+	}
+	return e.Stop.Sub(e.Start)
+}
+
+func (e *ClockEntry) String() string {
+	var stop string
+	if e.Stop != nil {
+		stop = e.Stop.Format(time.RFC3339)
+	}
+	return strings.Join([]string{e.Start.Format(time.RFC3339), stop, e.key, e.Text}, "\t")
+}
+
+func parseClockEntry(line string) (*ClockEntry, error) {
+	parts := strings.SplitN(line, "\t", 4)
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%w: clock entry '%s'", terrors.ErrParse, line)
+	}
+	start, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: clock entry start: %w", terrors.ErrParse, err)
+	}
+	out := &ClockEntry{Start: start.Local(), key: parts[2], Text: parts[3]}
+	if parts[1] != "" {
+		stop, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: clock entry stop: %w", terrors.ErrParse, err)
+		}
+		out.Stop = utils.MkPtr(stop.Local())
+	}
+	return out, nil
+}
+
+// the logs of a list sit next to its done file under _etc
+func etcLogPath(path, ext string) (string, error) {
+	path, err := parseFilepath(path)
+	if err != nil {
+		return "", err
+	}
+	path = strings.TrimPrefix(path, todosDir())
+	return filepath.Join(etcDir(), path+ext), nil
+}
+
+func timeLogPath(path string) (string, error) {
+	return etcLogPath(path, ".time")
+}
+
+// creates the directory of the list under _etc for its logs
+func mkEtcDirs(path string) error {
+	path, err := parseFilepath(path)
+	if err != nil {
+		return err
+	}
+	return mkDirs(filepath.Dir(path))
+}
+
+// the entries of the time log of the list; malformed lines are skipped
+func readTimeLog(path string) ([]*ClockEntry, error) {
+	logPath, err := timeLogPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*ClockEntry
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if entry, err := parseClockEntry(line); err == nil {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func writeTimeLog(path string, entries []*ClockEntry) error {
+	logPath, err := timeLogPath(path)
+	if err != nil {
+		return err
+	}
+	if err = mkEtcDirs(path); err != nil {
+		return err
+	}
+	var lines []string
+	for _, entry := range entries {
+		lines = append(lines, entry.String())
+	}
+	return os.WriteFile(logPath, []byte(strings.Join(lines, "\n")), 0o644)
+}
+
+// closes the running entries matching cond; returns how many were closed
+func stopClocks(entries []*ClockEntry, cond func(*ClockEntry) bool) int {
+	var count int
+	for _, entry := range entries {
+		if entry.Stop == nil && cond(entry) {
+			entry.Stop = utils.MkPtr(rightNow)
+			count++
+		}
+	}
+	return count
+}
+
+// clocks in the task; a timer running for another task of the list is stopped.
+func StartClock(id int, path string) error {
+	t, err := getTaskFromId(id, path)
+	if err != nil {
+		return err
+	}
+	entries, err := readTimeLog(path)
+	if err != nil {
+		return err
+	}
+	key := taskKey(t)
+	for _, entry := range entries {
+		if entry.Stop == nil && entry.key == key {
+			return fmt.Errorf("%w: task '%d' is already clocked in since %s", terrors.ErrValue, id, formatClockDatetime(entry.Start))
+		}
+	}
+	stopClocks(entries, func(*ClockEntry) bool { return true })
+	entries = append(entries, &ClockEntry{Start: rightNow, Text: t.Raw(), key: key})
+	return writeTimeLog(path, entries)
+}
+
+// clocks out the task, or every running timer of the list if id is nil
+func StopClock(id *int, path string) error {
+	cond := func(*ClockEntry) bool { return true }
+	if id != nil {
+		t, err := getTaskFromId(*id, path)
+		if err != nil {
+			return err
+		}
+		key := taskKey(t)
+		cond = func(entry *ClockEntry) bool { return entry.key == key }
+	} else if _, err := prepFileTaskFromPath(path); err != nil {
+		return err
+	}
+	entries, err := readTimeLog(path)
+	if err != nil {
+		return err
+	}
+	if stopClocks(entries, cond) == 0 {
+		if id != nil {
+			return fmt.Errorf("%w: no running timer for task '%d'", terrors.ErrNotFound, *id)
+		}
+		return fmt.Errorf("%w: no running timer in list '%s'", terrors.ErrNotFound, ListName(path))
+	}
+	return writeTimeLog(path, entries)
+}
+
+// the start of the running timers of the list by the key of their task
+func runningClocks(path string) (map[string]time.Time, error) {
+	entries, err := readTimeLog(path)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]time.Time)
+	for _, entry := range entries {
+		if entry.Stop == nil {
+			out[entry.key] = entry.Start
+		}
+	}
+	return out, nil
+}
+
+// "1h05m" and such; tracked time is only shown to the minute
+func formatTracked(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	if h := int(d.Hours()); h > 0 {
+		return fmt.Sprintf("%dh%02dm", h, int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+func formatClockDatetime(dt time.Time) string {
+	return dt.In(LocalZone()).Format("2006-01-02T15:04")
+}
+
+// the tracked time of a group of a report
+type TimeTotal struct {
+	Group    string
+	Duration time.Duration
+}
+
+// the start of a report; a datetime or a bare duration looked back from now
+// e.g. 1w for the last week. a start in the future is rejected.
+func ParseSince(value string) (*time.Time, error) {
+	if dur, err := parseDuration(value); err == nil {
+		return utils.MkPtr(rightNow.Add(-dur.Abs())), nil
+	}
+	since, err := ParseDatetime(value)
+	if err != nil {
+		return nil, err
+	}
+	if since.After(rightNow) {
+		return nil, fmt.Errorf("%w: '%s' is in the future", terrors.ErrValue, value)
+	}
+	return since, nil
+}
+
+// adds up the tracked time of the given lists since the given time (if not nil)
+// by hint, list or day. a task with several hints counts towards each of them.
+func TimeReport(paths []string, by string, since *time.Time) ([]TimeTotal, error) {
+	if !slices.Contains([]string{"hint", "list", "day"}, by) {
+		return nil, fmt.Errorf("%w: report by must be one of 'hint', 'list' or 'day' not '%s'", terrors.ErrValue, by)
+	}
+	totals := make(map[string]time.Duration)
+	for _, path := range paths {
+		path, err := parseFilepath(path)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := readTimeLog(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			start, stop := entry.Start, entry.Start.Add(entry.duration())
+			if since != nil && start.Before(*since) {
+				start = *since
+			}
+			if !stop.After(start) {
+				continue
+			}
+			switch by {
+			case "list":
+				totals[ListName(path)] += stop.Sub(start)
+			case "hint":
+				var hints []*string
+				if t, err := ParseTask(nil, entry.Text); err == nil {
+					hints = t.Hints
+				}
+				if len(hints) == 0 {
+					totals["-"] += stop.Sub(start)
+				}
+				for _, hint := range hints {
+					totals[*hint] += stop.Sub(start)
+				}
+			case "day": // spans crossing midnight are split among the days
+				for start.Before(stop) {
+					next := startOfDay(start).AddDate(0, 0, 1)
+					if next.After(stop) {
+						next = stop
+					}
+					totals[start.In(LocalZone()).Format("2006-01-02")] += next.Sub(start)
+					start = next
+				}
+			}
+		}
+	}
+	var out []TimeTotal
+	for group, dur := range totals {
+		out = append(out, TimeTotal{group, dur})
+	}
+	slices.SortFunc(out, func(l, r TimeTotal) int {
+		if by != "day" && l.Duration != r.Duration {
+			return cmp.Compare(r.Duration, l.Duration)
+		}
+		return strings.Compare(l.Group, r.Group)
+	})
+	return out, nil
+}
+
+func PrintTimeReport(paths []string, by string, since *time.Time) error {
+	totals, err := TimeReport(paths, by, since)
+	if err != nil {
+		return err
+	}
+	header := "> time | by " + by
+	if since != nil {
+		header += " since " + formatClockDatetime(*since)
+	}
+	header += " "
+	lines := []string{colorize("print.color-header", header+strings.Repeat("—", max(40-utils.RuneCount(header), 0)))}
+	groupLen := 5
+	for _, total := range totals {
+		groupLen = max(groupLen, utils.RuneCount(total.Group))
+	}
+	var sum time.Duration
+	for _, total := range totals {
+		sum += total.Duration
+		lines = append(lines, colorize("print.color-default", fmt.Sprintf("%-*s ", groupLen, total.Group))+
+			colorize("print.color-running-event", formatTracked(total.Duration)))
+	}
+	if by != "hint" { // hints overlap
+		lines = append(lines, colorize("print.color-index", fmt.Sprintf("%-*s ", groupLen, "total"))+
+			colorize("print.color-running-event", formatTracked(sum)))
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
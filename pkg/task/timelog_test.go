@@ -0,0 +1,98 @@
+package task
+
+import (
+	"dotxt/config"
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 1, day, hour, minute, 0, 0, time.Local)
+	}
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	path, _ := parseFilepath("clock")
+	Lists.Empty(path)
+	require.NoError(t, AddTaskFromStr("write +book @home $c=2025-01-10T09", path))
+	require.NoError(t, AddTaskFromStr("mail $c=2025-01-10T09", path))
+
+	rightNow = at(14, 23, 0)
+	require.NoError(t, StartClock(0, path))
+	assert.ErrorIs(StartClock(0, path), terrors.ErrValue)
+	rightNow = at(15, 1, 30)
+	require.NoError(t, StartClock(1, path)) // stops the first one
+	rightNow = at(15, 2, 0)
+	assert.ErrorIs(StopClock(utils.MkPtr(0), path), terrors.ErrNotFound)
+
+	t.Run("running", func(t *testing.T) {
+		clocks, err := runningClocks(path)
+		require.NoError(t, err)
+		require.Len(t, clocks, 1)
+		assert.Equal(at(15, 1, 30), clocks[taskKey(Lists[path].Tasks[1])])
+
+		rtasks, _, err := RenderList(path)
+		require.NoError(t, err)
+		for _, rt := range rtasks {
+			if *rt.task.ID == 1 {
+				assert.Equal("1 mail ⏱30m", rt.stringify(false, 80))
+			}
+		}
+	})
+	t.Run("report", func(t *testing.T) {
+		totals, err := TimeReport([]string{path}, "hint", nil)
+		require.NoError(t, err)
+		assert.Equal([]TimeTotal{
+			{"+book", 150 * time.Minute}, {"@home", 150 * time.Minute}, {"-", 30 * time.Minute},
+		}, totals)
+
+		totals, err = TimeReport([]string{path}, "day", nil)
+		require.NoError(t, err)
+		assert.Equal([]TimeTotal{{"2025-01-14", time.Hour}, {"2025-01-15", 120 * time.Minute}}, totals)
+
+		totals, err = TimeReport([]string{path}, "list", utils.MkPtr(at(15, 1, 0)))
+		require.NoError(t, err)
+		assert.Equal([]TimeTotal{{"clock", time.Hour}}, totals)
+
+		_, err = TimeReport([]string{path}, "week", nil)
+		assert.ErrorIs(err, terrors.ErrValue)
+	})
+	t.Run("since", func(t *testing.T) {
+		since, err := ParseSince("1h") // looks back from now
+		require.NoError(t, err)
+		assert.Equal(at(15, 1, 0), *since)
+		since, err = ParseSince("-1h")
+		require.NoError(t, err)
+		assert.Equal(at(15, 1, 0), *since)
+		totals, err := TimeReport([]string{path}, "list", since)
+		require.NoError(t, err)
+		assert.Equal([]TimeTotal{{"clock", time.Hour}}, totals)
+
+		since, err = ParseSince("2025-01-15")
+		require.NoError(t, err)
+		assert.Equal(at(15, 0, 0), *since)
+		_, err = ParseSince("2025-01-16")
+		assert.ErrorIs(err, terrors.ErrValue)
+	})
+	t.Run("stop", func(t *testing.T) {
+		require.NoError(t, StopClock(nil, path))
+		assert.ErrorIs(StopClock(nil, path), terrors.ErrNotFound)
+		rightNow = at(15, 5, 0)
+		totals, err := TimeReport([]string{path}, "list", nil)
+		require.NoError(t, err)
+		assert.Equal([]TimeTotal{{"clock", 3 * time.Hour}}, totals)
+	})
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// how pressing the dates of a task are; higher is more pressing
+type Urgency int
+
+const (
+	UrgencyNone Urgency = iota
+	UrgencySoon
+	UrgencyImminent
+	UrgencyOverdue
+)
+
+func (u Urgency) String() string {
+	switch u {
+	case UrgencySoon:
+		return "soon"
+	case UrgencyImminent:
+		return "imminent"
+	case UrgencyOverdue:
+		return "overdue"
+	}
+	return "none"
+}
+
+// the color key of the level; none has no color of its own
+func (u Urgency) color() string {
+	switch u {
+	case UrgencySoon:
+		return "print.color-urgent"
+	case UrgencyImminent:
+		return "print.color-imminent"
+	case UrgencyOverdue:
+		return "print.color-overdue"
+	}
+	return ""
+}
+
+func configDuration(key string, fallback time.Duration) time.Duration {
+	dur, err := parseDuration(viper.GetString(key))
+	if err != nil {
+		return fallback
+	}
+	return *dur
+}
+
+// 'urgency.window'; dates closer than it are urgent
+func urgencyWindow() time.Duration {
+	return configDuration("urgency.window", 30*24*time.Hour)
+}
+
+// 'urgency.imminent'; dates closer than it are imminent
+func imminentWindow() time.Duration {
+	return configDuration("urgency.imminent", 2*24*time.Hour)
+}
+
+// the window of a list or hint from 'urgency.lists' or 'urgency.hints'
+func namedUrgencyWindow(table, name string) (time.Duration, bool) {
+	val, ok := viper.GetStringMapString("urgency." + table)[strings.ToLower(name)]
+	if !ok {
+		return 0, false
+	}
+	dur, err := parseDuration(val)
+	if err != nil {
+		return 0, false
+	}
+	return *dur, true
+}
+
+// the window of the task; the widest of its hints, else its list's, else the global one
+func (t *Task) urgencyWindow() time.Duration {
+	var window time.Duration
+	var found bool
+	for _, hint := range t.Hints {
+		if dur, ok := namedUrgencyWindow("hints", *hint); ok && (!found || dur > window) {
+			window, found = dur, true
+		}
+	}
+	if found {
+		return window
+	}
+	if t.list != "" {
+		if dur, ok := namedUrgencyWindow("lists", ListName(t.list)); ok {
+			return dur
+		}
+	}
+	return urgencyWindow()
+}
+
+// the level of a date that has not passed yet
+func dateUrgency(dt *time.Time, window time.Duration) Urgency {
+	if dt == nil || !dt.After(rightNow) {
+		return UrgencyNone
+	}
+	left := dt.Sub(rightNow)
+	if left <= min(imminentWindow(), window) {
+		return UrgencyImminent
+	} else if left <= window {
+		return UrgencySoon
+	}
+	return UrgencyNone
+}
+
+// the level of one of the due, end or dead dates of the task.
+// a passed deadline is overdue and so is a passed due date unless
+// the task has an end or a deadline; a passed end date is of no concern.
+func (t *Task) fieldUrgency(field string) Urgency {
+	var dt *time.Time
+	switch field {
+	case "due":
+		dt = t.Time.DueDate
+	case "end":
+		dt = t.Time.EndDate
+	case "dead":
+		dt = t.Time.Deadline
+	}
+	return t.urgencyAt(field, dt)
+}
+
+// the level of the given date standing in for the field of the task;
+// e.g. the deadline of an upcoming occurrence of it
+func (t *Task) urgencyAt(field string, dt *time.Time) Urgency {
+	if dt == nil {
+		return UrgencyNone
+	}
+	if !dt.After(rightNow) {
+		if field == "dead" || (field == "due" && t.Time.EndDate == nil && t.Time.Deadline == nil) {
+			return UrgencyOverdue
+		}
+		return UrgencyNone
+	}
+	return dateUrgency(dt, t.urgencyWindow())
+}
+
+// the highest level among the dates of the task
+func (t *Task) Urgency() Urgency {
+	return max(t.fieldUrgency("due"), t.fieldUrgency("end"), t.fieldUrgency("dead"))
+}
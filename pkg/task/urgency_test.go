@@ -0,0 +1,113 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUrgency(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local)
+	for _, key := range []string{"urgency.window", "urgency.imminent", "urgency.lists", "urgency.hints"} {
+		defer viper.Set(key, viper.Get(key))
+	}
+	viper.Set("urgency.window", "1m")
+	viper.Set("urgency.imminent", "2d")
+	viper.Set("urgency.lists", map[string]any{})
+	viper.Set("urgency.hints", map[string]any{})
+
+	level := func(line string) Urgency {
+		task, err := ParseTask(nil, line+" $c=2025-01-01")
+		require.NoError(t, err)
+		return task.Urgency()
+	}
+	t.Run("levels", func(t *testing.T) {
+		assert.Equal(UrgencyNone, level("a"))
+		assert.Equal(UrgencyNone, level("a $due=2025-03-20"))
+		assert.Equal(UrgencySoon, level("a $due=2025-01-22"))
+		assert.Equal(UrgencyImminent, level("a $due=2025-01-16"))
+		assert.Equal(UrgencyImminent, level("a $due=2025-01-10 $dead=2025-01-16"))
+		assert.Equal(UrgencyOverdue, level("a $due=2025-01-14"))
+		assert.Equal(UrgencyOverdue, level("a $due=2025-01-13 $dead=2025-01-14"))
+		assert.Equal(UrgencySoon, level("a $due=2025-01-14 $end=2025-01-22"))
+		assert.Equal(UrgencyNone, level("a $due=2025-01-13 $end=2025-01-14"))
+		assert.Equal("imminent", UrgencyImminent.String())
+	})
+	t.Run("windows", func(t *testing.T) {
+		viper.Set("urgency.window", "1w")
+		assert.Equal(UrgencyNone, level("a $due=2025-01-29T09"))
+		viper.Set("urgency.hints", map[string]any{"+work": "3w", "#later": "1d"})
+		assert.Equal(UrgencySoon, level("a +work $due=2025-01-29T09"))
+		assert.Equal(UrgencySoon, level("a +Work #later $due=2025-01-29T09"))
+		assert.Equal(UrgencyNone, level("a #later $due=2025-01-17T09"))
+		// the imminent window never exceeds the one of the task
+		assert.Equal(UrgencyImminent, level("a #later $due=2025-01-15T21"))
+
+		path, _ := parseFilepath("urgency")
+		Lists.Empty(path)
+		viper.Set("urgency.lists", map[string]any{"urgency": "1m"})
+		require.NoError(t, AddTaskFromStr("a $c=2025-01-15T09 $due=2w", path))
+		require.NoError(t, AddTaskFromStr("b #later $c=2025-01-15T09 $due=2w", path))
+		assert.Equal(UrgencySoon, Lists[path].Tasks[0].Urgency())
+		assert.Equal(UrgencyNone, Lists[path].Tasks[1].Urgency())
+		require.NoError(t, Lists[path].Tasks[0].updateFromText("c $due=3w"))
+		assert.Equal(UrgencySoon, Lists[path].Tasks[0].Urgency())
+	})
+	t.Run("urgent", func(t *testing.T) {
+		viper.Set("urgency.window", "1w")
+		viper.Set("urgency.hints", map[string]any{"+work": "3w"})
+		urgent := func(line string) bool {
+			task, err := ParseTask(nil, line+" $c=2025-01-01")
+			require.NoError(t, err)
+			return task.IsUrgent()
+		}
+		assert.True(urgent("a $due=2025-01-14")) // overdue
+		assert.True(urgent("a $due=2025-01-20"))
+		assert.False(urgent("a $due=2025-01-29T09"))
+		assert.True(urgent("a +work $due=2025-01-29T09"))
+		assert.False(urgent("a $due=2025-01-13 $end=2025-01-14"))
+		assert.True(urgent("a $urgent"))
+	})
+	t.Run("colors", func(t *testing.T) {
+		viper.Set("urgency.window", "1w")
+		viper.Set("urgency.hints", map[string]any{"+work": "3w"})
+		path, _ := parseFilepath("urgency-colors")
+		Lists.Empty(path)
+		require.NoError(t, AddTaskFromStr("a +work $c=2025-01-10 $due=2025-01-20 $dead=2025-01-29T09", path))
+		require.NoError(t, AddTaskFromStr("b $c=2025-01-10 $due=2025-01-20 $dead=2025-01-29T09", path))
+		require.NoError(t, AddTaskFromStr("c $c=2025-01-10 $due=2025-01-15T12 $dead=2025-01-16", path))
+		day := time.Date(2025, 1, 29, 0, 0, 0, 0, time.Local)
+		occs, err := Occurrences([]string{path}, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		var colors []string
+		for _, item := range calendarDay(occs, day) {
+			colors = append(colors, item.color)
+		}
+		assert.Equal([]string{"print.color-urgent", "print.color-date-dead"}, colors)
+
+		task := Lists[path].Tasks[2]
+		occ := &Occurrence{Task: task, Due: *task.Time.DueDate, Dead: task.Time.Deadline}
+		assert.Equal("print.color-imminent", (&agendaEntry{occ: occ, at: occ.Due, kind: "due"}).color())
+		assert.Equal("print.color-imminent", (&agendaEntry{occ: occ, at: *occ.Dead, kind: "dead"}).color())
+	})
+	t.Run("sort", func(t *testing.T) {
+		viper.Set("urgency.window", "1m")
+		viper.Set("urgency.hints", map[string]any{})
+		tasks := make([]*Task, 0)
+		for _, line := range []string{"a $due=2025-02-05", "b $due=2025-01-16", "c $due=2025-01-14", "d $due=2025-03-20"} {
+			task, err := ParseTask(nil, line+" $c=2025-01-01")
+			require.NoError(t, err)
+			tasks = append(tasks, task)
+		}
+		var order []string
+		for _, task := range sortTasks(tasks) {
+			order = append(order, task.NormRegular())
+		}
+		assert.Equal([]string{"c", "b", "a", "d"}, order)
+	})
+}
@@ -0,0 +1,203 @@
+package task
+
+import (
+	"dotxt/config"
+	"dotxt/pkg/terrors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// an offset counted in working days (bd) or working hours (bh)
+type WorkOffset struct {
+	N    int
+	Unit string
+}
+
+func (w *WorkOffset) String() string {
+	return fmt.Sprintf("%d%s", w.N, w.Unit)
+}
+
+// e.g. 3bd, -2bd or 4bh
+func parseWorkOffset(value string) (*WorkOffset, error) {
+	var unit string
+	for _, u := range []string{"bd", "bh"} {
+		if strings.HasSuffix(value, u) {
+			unit = u
+		}
+	}
+	if unit == "" {
+		return nil, fmt.Errorf("%w: work offset '%s' must end in 'bd' or 'bh'", terrors.ErrParse, value)
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSuffix(value, unit), "+"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: work offset '%s': %w", terrors.ErrParse, value, err)
+	}
+	return &WorkOffset{N: n, Unit: unit}, nil
+}
+
+func parseTmpWorkRelativeDatetime(field, dt string) (string, *WorkOffset, error) {
+	fallback, dt, err := getTemporalFallback(field, dt)
+	if err != nil {
+		return "", nil, err
+	}
+	offset, err := parseWorkOffset(dt)
+	if err != nil {
+		return "", nil, err
+	}
+	return fallback, offset, nil
+}
+
+// the base moved by the offset
+func (w *WorkOffset) apply(base time.Time) time.Time {
+	if w.Unit == "bh" {
+		return addWorkHours(base, w.N)
+	}
+	return addWorkDays(base, w.N)
+}
+
+// the configured working days; 'time.work-days' as comma separated weekdays
+func workDays() map[time.Weekday]bool {
+	out := make(map[time.Weekday]bool)
+	for day := range strings.SplitSeq(strings.ToLower(viper.GetString("time.work-days")), ",") {
+		if wd, err := parseWeekday(strings.TrimSpace(day)); err == nil {
+			out[wd] = true
+		}
+	}
+	if len(out) == 0 {
+		for wd := time.Monday; wd <= time.Friday; wd++ {
+			out[wd] = true
+		}
+	}
+	return out
+}
+
+// the configured working hours as minutes into the day; 'time.work-hours' as HH:MM-HH:MM
+func workHours() (int, int) {
+	startStr, endStr, _ := strings.Cut(viper.GetString("time.work-hours"), "-")
+	sh, sm, err1 := parseClock(strings.TrimSpace(startStr))
+	eh, em, err2 := parseClock(strings.TrimSpace(endStr))
+	if err1 != nil || err2 != nil || sh*60+sm >= eh*60+em {
+		return 9 * 60, 17 * 60
+	}
+	return sh*60 + sm, eh*60 + em
+}
+
+var holidayCache struct {
+	path    string
+	modTime time.Time
+	days    map[string]bool
+}
+
+// the holidays file of the config directory holds a date (YYYY-MM-DD)
+// at the start of each line; everything after it and lines starting with '#' are ignored
+func holidaysPath() string {
+	return filepath.Join(config.ConfigPath(), "holidays")
+}
+
+func holidays() map[string]bool {
+	path := holidaysPath()
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if holidayCache.path == path && holidayCache.modTime.Equal(info.ModTime()) {
+		return holidayCache.days
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	days := make(map[string]bool)
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, _, _ := strings.Cut(line, " ")
+		if _, err := time.Parse("2006-01-02", date); err == nil {
+			days[date] = true
+		}
+	}
+	holidayCache.path, holidayCache.modTime, holidayCache.days = path, info.ModTime(), days
+	return days
+}
+
+func isHoliday(dt time.Time) bool {
+	return holidays()[dt.In(LocalZone()).Format("2006-01-02")]
+}
+
+func isWorkDay(dt time.Time, days map[time.Weekday]bool) bool {
+	return days[dt.In(LocalZone()).Weekday()] && !isHoliday(dt)
+}
+
+// moves n working days from dt keeping its time of day; a year without any is given up on
+func addWorkDays(dt time.Time, n int) time.Time {
+	days := workDays()
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for tries := 0; n > 0 && tries < 366; tries++ {
+		dt = dt.AddDate(0, 0, step)
+		if isWorkDay(dt, days) {
+			n, tries = n-1, 0
+		}
+	}
+	return dt
+}
+
+// moves n working hours from dt counting only the working hours of working days;
+// a start outside of them is first moved to the closest edge in the direction.
+func addWorkHours(dt time.Time, n int) time.Time {
+	days := workDays()
+	start, end := workHours()
+	at := func(day time.Time, minutes int) time.Time {
+		return startOfDay(day).Add(time.Duration(minutes) * time.Minute)
+	}
+	remaining := time.Duration(n) * time.Hour
+	forward := remaining >= 0
+	if !forward {
+		remaining = -remaining
+	}
+	for tries := 0; remaining > 0 && tries < 366; tries++ {
+		day := startOfDay(dt)
+		if !isWorkDay(day, days) {
+			if forward {
+				dt = at(day.AddDate(0, 0, 1), start)
+			} else {
+				dt = at(day.AddDate(0, 0, -1), end)
+			}
+			continue
+		}
+		open, closed := at(day, start), at(day, end)
+		if forward {
+			if dt.Before(open) {
+				dt = open
+			}
+			if !dt.Before(closed) {
+				dt = at(day.AddDate(0, 0, 1), start)
+				continue
+			}
+			step := min(remaining, closed.Sub(dt))
+			dt, remaining = dt.Add(step), remaining-step
+		} else {
+			if dt.After(closed) {
+				dt = closed
+			}
+			if !dt.After(open) {
+				dt = at(day.AddDate(0, 0, -1), end)
+				continue
+			}
+			step := min(remaining, dt.Sub(open))
+			dt, remaining = dt.Add(-step), remaining-step
+		}
+		tries = 0
+	}
+	return dt
+}
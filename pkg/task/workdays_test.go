@@ -0,0 +1,118 @@
+package task
+
+import (
+	"dotxt/config"
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkOffsets(t *testing.T) {
+	assert := assert.New(t)
+	defer AdjustTime()
+	rightNow = time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local) // wednesday
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 1, day, hour, 0, 0, 0, time.Local)
+	}
+
+	prevConfig := config.ConfigPath()
+	defer config.SelectConfigFile(prevConfig)
+	tmpDir, err := os.MkdirTemp(prevConfig, "")
+	require.Nil(t, err)
+	config.SelectConfigFile(tmpDir)
+
+	t.Run("parse", func(t *testing.T) {
+		w, err := parseWorkOffset("3bd")
+		require.NoError(t, err)
+		assert.Equal(WorkOffset{3, "bd"}, *w)
+		w, err = parseWorkOffset("-4bh")
+		require.NoError(t, err)
+		assert.Equal(WorkOffset{-4, "bh"}, *w)
+		for _, value := range []string{"3d", "bd", "1.5bd", "3bdx"} {
+			_, err := parseWorkOffset(value)
+			assert.Error(err, value)
+		}
+		for _, value := range []string{"2bd", "-4bh"} { // only in relative datetimes
+			_, err := parseDuration(value)
+			assert.ErrorIs(err, terrors.ErrParse, value)
+		}
+	})
+	t.Run("days", func(t *testing.T) {
+		assert.Equal(at(20, 10), addWorkDays(at(15, 10), 3))
+		assert.Equal(at(10, 10), addWorkDays(at(15, 10), -3))
+		assert.Equal(at(20, 10), addWorkDays(at(18, 10), 1))
+		assert.Equal(at(15, 10), addWorkDays(at(15, 10), 0))
+	})
+	t.Run("hours", func(t *testing.T) {
+		assert.Equal(at(16, 11), addWorkHours(at(15, 15), 4))
+		assert.Equal(at(20, 10), addWorkHours(at(17, 16), 2))
+		assert.Equal(at(20, 10), addWorkHours(at(18, 12), 1))
+		assert.Equal(at(15, 16), addWorkHours(at(16, 10), -2))
+		assert.Equal(at(15, 17), addWorkHours(at(15, 13), 4))
+	})
+	t.Run("configured", func(t *testing.T) {
+		defer viper.Set("time.work-days", viper.GetString("time.work-days"))
+		defer viper.Set("time.work-hours", viper.GetString("time.work-hours"))
+		viper.Set("time.work-days", "sat,sun,mon,tue,wed")
+		viper.Set("time.work-hours", "08:00-12:00")
+		assert.Equal(at(18, 10), addWorkDays(at(15, 10), 1))
+		assert.Equal(at(18, 9), addWorkHours(at(15, 11), 2))
+
+		rec, err := parseRecurrence("weekdays")
+		require.NoError(t, err)
+		next, ok := rec.Next(at(15, 10), at(15, 10))
+		assert.True(ok)
+		assert.Equal(at(18, 10), next)
+		rec, err = parseRecurrence("weekends")
+		require.NoError(t, err)
+		next, ok = rec.Next(at(15, 10), at(15, 10))
+		assert.True(ok)
+		assert.Equal(at(16, 10), next)
+	})
+	t.Run("holidays", func(t *testing.T) {
+		data := "# winter break\n2025-01-20 some holiday\n\n2025-01-18\nnonsense\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "holidays"), []byte(data), 0o644))
+		assert.Equal(at(21, 10), addWorkDays(at(15, 10), 3))
+		assert.Equal(at(21, 10), addWorkHours(at(17, 16), 2))
+
+		tm := &Temporal{Every: utils.MkPtr(24 * time.Hour)}
+		next, ok := tm.nextOccurrence(at(17, 9), at(17, 9))
+		assert.True(ok)
+		assert.Equal(at(19, 9), next)
+		require.NoError(t, os.Remove(filepath.Join(tmpDir, "holidays")))
+	})
+	t.Run("tokens", func(t *testing.T) {
+		task, err := ParseTask(nil, "x $c=2025-01-10T09 $due=2025-01-17T12 $dead=due:2bd $r=due:-1bh")
+		require.NoError(t, err)
+		assert.Equal(at(21, 12), *task.Time.Deadline)
+		assert.Equal(at(17, 11), *task.Time.Reminders[0])
+		assert.Equal("x $c=2025-01-10T09 $due=2025-01-17T12 $dead=due:2bd $r=due:-1bh", task.Raw())
+
+		task.shiftDates(7 * 24 * time.Hour)
+		assert.Equal(at(24, 12), *task.Time.DueDate)
+		assert.Equal(at(28, 12), *task.Time.Deadline)
+		assert.Equal("x $c=2025-01-10T09 $due=2025-01-24T12 $dead=due:2bd $r=due:-1bh", task.Raw())
+
+		require.NoError(t, task.updateDate("dead", utils.MkPtr(at(29, 12))))
+		assert.Equal("x $c=2025-01-10T09 $due=2025-01-24T12 $dead=due:5d $r=due:-1bh", task.Raw())
+	})
+	t.Run("recur", func(t *testing.T) {
+		defer func(now time.Time) { rightNow = now }(rightNow)
+		rightNow = at(16, 12)
+		path, _ := parseFilepath("workdays")
+		Lists.Empty(path)
+		require.NoError(t, AddTaskFromStr("x $c=2025-01-10T09 $due=2025-01-13T09 $every=1d $dead=due:3bd", path))
+		require.NoError(t, CheckAndRecurTasks(path))
+		task := Lists[path].Tasks[0]
+		assert.Equal(at(17, 9), *task.Time.DueDate)
+		assert.Equal(at(22, 9), *task.Time.Deadline)
+		assert.Equal("x $c=2025-01-10T09 $due=2025-01-17T09 $every=1d $dead=due:3bd", task.Raw())
+	})
+}
@@ -0,0 +1,38 @@
+package task
+
+import (
+	"dotxt/pkg/utils"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var zoneCache struct {
+	name string
+	loc  *time.Location
+}
+
+// the zone in which datetimes without an explicit zone are read and shown;
+// 'time.zone' if set, otherwise the system's
+func LocalZone() *time.Location {
+	name := viper.GetString("time.zone")
+	if name == "" {
+		return time.Local
+	}
+	if zoneCache.loc == nil || zoneCache.name != name {
+		loc, err := utils.ParseZone(name)
+		if err != nil {
+			return time.Local
+		}
+		zoneCache.name, zoneCache.loc = name, loc
+	}
+	return zoneCache.loc
+}
+
+// the zone suffix of a datetime stored in its own zone; empty for the local zone
+func zoneSuffix(dt time.Time) string {
+	if name := dt.Location().String(); name != LocalZone().String() {
+		return "@" + name
+	}
+	return ""
+}
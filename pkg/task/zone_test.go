@@ -0,0 +1,58 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZonedDatetime(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("suffix", func(t *testing.T) {
+		dt, err := parseAbsoluteDatetime("2025-05-05T10@UTC")
+		require.NoError(t, err)
+		assert.Equal(time.UTC, dt.Location())
+		assert.Equal(10, dt.Hour())
+		assert.Equal("2025-05-05T10@UTC", unparseAbsoluteDatetime(*dt))
+
+		dt, err = parseAbsoluteDatetime("2025-05-05T10-30@+03:30")
+		require.NoError(t, err)
+		_, offset := dt.Zone()
+		assert.Equal(3*60*60+30*60, offset)
+		assert.Equal("2025-05-05T10-30@+0330", unparseAbsoluteDatetime(*dt))
+
+		dt, err = parseAbsoluteDatetime("2025-05@Europe/Berlin")
+		require.NoError(t, err)
+		assert.Equal("Europe/Berlin", dt.Location().String())
+		assert.Equal("2025-05@Europe/Berlin", unparseAbsoluteDatetime(*dt))
+
+		_, err = parseAbsoluteDatetime("2025-05-05@Nowhere/City")
+		assert.Error(err)
+		_, err = parseAbsoluteDatetime("@UTC")
+		assert.Error(err)
+	})
+	t.Run("configured zone", func(t *testing.T) {
+		viper.Set("time.zone", "Asia/Tokyo")
+		defer viper.Set("time.zone", "")
+
+		dt, err := parseAbsoluteDatetime("2025-05-05T10")
+		require.NoError(t, err)
+		assert.Equal("Asia/Tokyo", dt.Location().String())
+		assert.Equal("2025-05-05T10", unparseAbsoluteDatetime(*dt))
+
+		utc := time.Date(2025, 5, 5, 1, 0, 0, 0, time.UTC)
+		assert.Equal("2025-05-05T01@UTC", unparseAbsoluteDatetime(utc))
+		assert.Equal("2025-05-05T10-00", formatAbsoluteDatetime(&utc, nil))
+		assert.Equal("2025-05-05T10", unparseAbsoluteDatetime(utc.In(time.Local)))
+	})
+	t.Run("task", func(t *testing.T) {
+		task, err := ParseTask(nil, "call $c=2025-05-01T10@UTC $due=2025-05-05T10@America/New_York")
+		require.NoError(t, err)
+		assert.Equal("call $due=2025-05-05T10@America/New_York", task.Norm())
+		assert.Equal(time.Date(2025, 5, 5, 14, 0, 0, 0, time.UTC), task.Time.DueDate.UTC())
+	})
+}
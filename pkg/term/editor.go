@@ -0,0 +1,182 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var ErrInterrupted = errors.New("interrupted")
+
+// A single line editor for terminals in raw mode
+type Editor struct {
+	In  *Reader
+	Out io.Writer
+	// previous lines, oldest first; browsed with up/down
+	History []string
+	// candidates for the word before the cursor given the text before the cursor;
+	// a candidate may carry a description after a tab, e.g. "value\tdescription"
+	Complete func(text string) []string
+}
+
+// reads a line of input with initial as the editable starting text.
+// returns ErrInterrupted on ctrl-c or esc, and io.EOF on ctrl-d upon an empty line.
+func (e *Editor) ReadLine(prompt, initial string) (string, error) {
+	buf := []rune(initial)
+	pos := len(buf)
+	histNdx, draft := len(e.History), buf
+	tabbed := false
+	redraw := func() {
+		fmt.Fprintf(e.Out, "\r\x1b[K%s%s", prompt, string(buf))
+		if n := len(buf) - pos; n > 0 {
+			fmt.Fprintf(e.Out, "\x1b[%dD", n)
+		}
+	}
+	redraw()
+	for {
+		key, err := e.In.ReadKey()
+		if err != nil {
+			return "", err
+		}
+		if key.Code != KeyTab {
+			tabbed = false
+		}
+		switch key.Code {
+		case KeyTab:
+			if e.Complete != nil {
+				buf, pos = e.complete(buf, pos, tabbed)
+				tabbed = true
+			}
+		case KeyUp, KeyDown:
+			if key.Code == KeyUp && histNdx > 0 {
+				if histNdx == len(e.History) {
+					draft = buf
+				}
+				histNdx--
+			} else if key.Code == KeyDown && histNdx < len(e.History) {
+				histNdx++
+			} else {
+				break
+			}
+			if histNdx == len(e.History) {
+				buf = draft
+			} else {
+				buf = []rune(e.History[histNdx])
+			}
+			pos = len(buf)
+		case KeyEnter:
+			return string(buf), nil
+		case KeyEsc:
+			return "", ErrInterrupted
+		case KeyBackspace:
+			if pos > 0 {
+				buf = slices.Delete(buf, pos-1, pos)
+				pos--
+			}
+		case KeyDelete:
+			if pos < len(buf) {
+				buf = slices.Delete(buf, pos, pos+1)
+			}
+		case KeyLeft:
+			pos = max(pos-1, 0)
+		case KeyRight:
+			pos = min(pos+1, len(buf))
+		case KeyHome:
+			pos = 0
+		case KeyEnd:
+			pos = len(buf)
+		case KeyRune:
+			buf = slices.Insert(buf, pos, key.Rune)
+			pos++
+		case KeyCtrl:
+			switch key.Rune {
+			case 'c':
+				return "", ErrInterrupted
+			case 'd':
+				if len(buf) == 0 {
+					return "", io.EOF
+				}
+				if pos < len(buf) {
+					buf = slices.Delete(buf, pos, pos+1)
+				}
+			case 'a':
+				pos = 0
+			case 'e':
+				pos = len(buf)
+			case 'b':
+				pos = max(pos-1, 0)
+			case 'f':
+				pos = min(pos+1, len(buf))
+			case 'u':
+				buf = slices.Delete(buf, 0, pos)
+				pos = 0
+			case 'k':
+				buf = buf[:pos]
+			case 'w':
+				start := pos
+				for start > 0 && unicode.IsSpace(buf[start-1]) {
+					start--
+				}
+				for start > 0 && !unicode.IsSpace(buf[start-1]) {
+					start--
+				}
+				buf = slices.Delete(buf, start, pos)
+				pos = start
+			}
+		}
+		redraw()
+	}
+}
+
+// completes the word before pos up to the longest common prefix of the candidates.
+// if that makes no progress and list is set, the candidates are printed below the line.
+func (e *Editor) complete(buf []rune, pos int, list bool) ([]rune, int) {
+	start := pos
+	for start > 0 && !unicode.IsSpace(buf[start-1]) {
+		start--
+	}
+	word := string(buf[start:pos])
+	candidates := e.Complete(string(buf[:pos]))
+	var values []string
+	for _, candidate := range candidates {
+		value, _, _ := strings.Cut(candidate, "\t")
+		if strings.HasPrefix(value, word) {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return buf, pos
+	}
+	common := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(values) == 1 && !strings.HasSuffix(common, "=") && !strings.HasSuffix(common, "/") {
+		common += " "
+	}
+	if common != word {
+		insert := []rune(common)
+		out := slices.Concat(buf[:start], insert, buf[pos:])
+		return out, start + len(insert)
+	}
+	if list && len(values) > 1 {
+		fmt.Fprint(e.Out, "\r\n")
+		for _, candidate := range candidates {
+			value, desc, _ := strings.Cut(candidate, "\t")
+			if !strings.HasPrefix(value, word) {
+				continue
+			}
+			if desc != "" {
+				fmt.Fprintf(e.Out, "%s  -- %s\r\n", value, desc)
+			} else {
+				fmt.Fprintf(e.Out, "%s\r\n", value)
+			}
+		}
+	}
+	return buf, pos
+}
@@ -0,0 +1,89 @@
+package term
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLine(t *testing.T) {
+	assert := assert.New(t)
+	readLine := func(input, initial string) (string, error) {
+		e := &Editor{In: NewReader(strings.NewReader(input)), Out: io.Discard}
+		return e.ReadLine("> ", initial)
+	}
+	t.Run("typing", func(t *testing.T) {
+		out, err := readLine("abc\r", "")
+		assert.NoError(err)
+		assert.Equal("abc", out)
+	})
+	t.Run("initial and editing", func(t *testing.T) {
+		out, err := readLine("\x1b[D\x1b[DX\x7f\x7fY\r", "abcd")
+		assert.NoError(err)
+		assert.Equal("aYcd", out)
+	})
+	t.Run("kill", func(t *testing.T) {
+		out, err := readLine("\x01\x0bnew\r", "old")
+		assert.NoError(err)
+		assert.Equal("new", out)
+		out, err = readLine("\x17\r", "two words")
+		assert.NoError(err)
+		assert.Equal("two ", out)
+	})
+	t.Run("interrupt", func(t *testing.T) {
+		_, err := readLine("ab\x03", "")
+		assert.ErrorIs(err, ErrInterrupted)
+		_, err = readLine("\x1b", "")
+		assert.ErrorIs(err, ErrInterrupted)
+	})
+	t.Run("eof", func(t *testing.T) {
+		_, err := readLine("\x04", "")
+		assert.ErrorIs(err, io.EOF)
+	})
+}
+
+func TestReadLineHistory(t *testing.T) {
+	assert := assert.New(t)
+	readLine := func(input string) string {
+		e := &Editor{
+			In: NewReader(strings.NewReader(input)), Out: io.Discard,
+			History: []string{"first", "second"},
+		}
+		out, err := e.ReadLine("> ", "")
+		assert.NoError(err)
+		return out
+	}
+	assert.Equal("second", readLine("\x1b[A\r"))
+	assert.Equal("first", readLine("\x1b[A\x1b[A\x1b[A\r"))
+	assert.Equal("draft", readLine("draft\x1b[A\x1b[B\r"))
+	assert.Equal("second!", readLine("\x1b[A\x1b[A\x1b[B!\r"))
+}
+
+func TestReadLineComplete(t *testing.T) {
+	assert := assert.New(t)
+	var out strings.Builder
+	readLine := func(input string) string {
+		out.Reset()
+		e := &Editor{
+			In: NewReader(strings.NewReader(input)), Out: &out,
+			Complete: func(text string) []string {
+				if strings.HasPrefix(text, "add ") {
+					return []string{"+project\thint", "+progress\thint", "$due=\tdue datetime"}
+				}
+				return []string{"add", "app", "del"}
+			},
+		}
+		line, err := e.ReadLine("> ", "")
+		assert.NoError(err)
+		return line
+	}
+	assert.Equal("del ", readLine("d\t\r"))
+	assert.Equal("a", readLine("a\t\r"))
+	assert.Equal("add ", readLine("ad\t\r"))
+	assert.Equal("add +pro", readLine("add +\t\r"))
+	assert.Equal("add $due=", readLine("add $\t\r"))
+	assert.Equal("add +pro", readLine("add +\t\t\r"))
+	assert.Contains(out.String(), "+progress  -- hint")
+}
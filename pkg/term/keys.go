@@ -0,0 +1,130 @@
+package term
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyEnter
+	KeyEsc
+	KeyTab
+	KeyBackTab
+	KeyBackspace
+	KeyDelete
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDown
+	KeyCtrl // Rune holds the lowercase letter; e.g. ctrl-c -> 'c'
+	KeyUnknown
+)
+
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// Reads keys out of a terminal in raw mode
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// escape sequences are recognized only when they arrive in full,
+// which is the case for terminals writing a whole sequence at once;
+// a lone ESC is reported as KeyEsc
+func (kr *Reader) ReadKey() (Key, error) {
+	b, err := kr.r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	switch b {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case '\t':
+		return Key{Code: KeyTab}, nil
+	case 127, 8:
+		return Key{Code: KeyBackspace}, nil
+	case 27:
+		if kr.r.Buffered() == 0 {
+			return Key{Code: KeyEsc}, nil
+		}
+		return kr.readEscape()
+	}
+	if b < 32 {
+		return Key{Code: KeyCtrl, Rune: rune('a' + b - 1)}, nil
+	}
+	if b < utf8.RuneSelf {
+		return Key{Code: KeyRune, Rune: rune(b)}, nil
+	}
+	if err := kr.r.UnreadByte(); err != nil {
+		return Key{}, err
+	}
+	r, _, err := kr.r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{Code: KeyRune, Rune: r}, nil
+}
+
+func (kr *Reader) readEscape() (Key, error) {
+	b, err := kr.r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	if b != '[' && b != 'O' {
+		return Key{Code: KeyUnknown}, nil
+	}
+	var params []byte
+	for {
+		c, err := kr.r.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if c >= 0x40 && c <= 0x7e { // final byte
+			switch c {
+			case 'A':
+				return Key{Code: KeyUp}, nil
+			case 'B':
+				return Key{Code: KeyDown}, nil
+			case 'C':
+				return Key{Code: KeyRight}, nil
+			case 'D':
+				return Key{Code: KeyLeft}, nil
+			case 'H':
+				return Key{Code: KeyHome}, nil
+			case 'F':
+				return Key{Code: KeyEnd}, nil
+			case 'Z':
+				return Key{Code: KeyBackTab}, nil
+			case '~':
+				switch string(params) {
+				case "1", "7":
+					return Key{Code: KeyHome}, nil
+				case "4", "8":
+					return Key{Code: KeyEnd}, nil
+				case "3":
+					return Key{Code: KeyDelete}, nil
+				case "5":
+					return Key{Code: KeyPgUp}, nil
+				case "6":
+					return Key{Code: KeyPgDown}, nil
+				}
+			}
+			return Key{Code: KeyUnknown}, nil
+		}
+		params = append(params, c)
+	}
+}
@@ -0,0 +1,44 @@
+package term
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadKey(t *testing.T) {
+	assert := assert.New(t)
+	kr := NewReader(strings.NewReader("a\r\t\x7f\x03\x1b[A\x1b[B\x1b[C\x1b[D\x1b[Z\x1b[3~\x1b[5~\x1bOHé"))
+	expected := []Key{
+		{Code: KeyRune, Rune: 'a'},
+		{Code: KeyEnter},
+		{Code: KeyTab},
+		{Code: KeyBackspace},
+		{Code: KeyCtrl, Rune: 'c'},
+		{Code: KeyUp},
+		{Code: KeyDown},
+		{Code: KeyRight},
+		{Code: KeyLeft},
+		{Code: KeyBackTab},
+		{Code: KeyDelete},
+		{Code: KeyPgUp},
+		{Code: KeyHome},
+		{Code: KeyRune, Rune: 'é'},
+	}
+	for _, exp := range expected {
+		key, err := kr.ReadKey()
+		require.NoError(t, err)
+		assert.Equal(exp, key)
+	}
+	_, err := kr.ReadKey()
+	assert.ErrorIs(err, io.EOF)
+
+	t.Run("lone escape", func(t *testing.T) {
+		key, err := NewReader(strings.NewReader("\x1b")).ReadKey()
+		require.NoError(t, err)
+		assert.Equal(KeyEsc, key.Code)
+	})
+}
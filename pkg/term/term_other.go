@@ -0,0 +1,27 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package term
+
+import "errors"
+
+// raw mode is not supported here; the terminal is never reported as one
+type State struct{}
+
+func IsTerminal(fd int) bool {
+	return false
+}
+
+func MakeRaw(fd int) (*State, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func Restore(fd int, state *State) error {
+	if state == nil {
+		return nil
+	}
+	return errors.ErrUnsupported
+}
+
+func Size(fd int) (int, int, error) {
+	return -1, -1, errors.ErrUnsupported
+}
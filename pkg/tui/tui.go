@@ -0,0 +1,417 @@
+package tui
+
+import (
+	"bufio"
+	"dotxt/config"
+	"dotxt/pkg/task"
+	"dotxt/pkg/term"
+	"dotxt/pkg/terrors"
+	"dotxt/pkg/utils"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const helpLine = "j/k move  tab list  ⏎ collapse  f focus  x done  p pri  +/- prog  e edit  a add  / filter  q quit"
+
+type app struct {
+	paths  []string
+	names  []string
+	cur    int
+	lines  []task.Line
+	cursor int // index of the selected line in lines
+	offset int // first visible row
+	filter string
+	status string
+	width  int
+	height int
+
+	fd     int
+	out    *bufio.Writer
+	editor *term.Editor
+}
+
+// runs the interactive interface upon the given lists until the user quits.
+// paths must already be absolute paths of existing lists.
+func Run(paths []string, start int) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("%w: stdin is not a terminal", terrors.ErrValue)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("%w: no lists to show", terrors.ErrNotFound)
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	prevColor := config.Color
+	config.Color = true
+	defer func() { config.Color = prevColor }()
+
+	a := &app{
+		paths: paths, cur: min(max(start, 0), len(paths)-1),
+		fd: fd, out: bufio.NewWriter(os.Stdout),
+	}
+	for _, path := range paths {
+		a.names = append(a.names, task.ListName(path))
+	}
+	in := term.NewReader(os.Stdin)
+	a.editor = &term.Editor{In: in, Out: a.out}
+
+	a.out.WriteString("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		a.out.WriteString("\x1b[0m\x1b[?25h\x1b[?1049l")
+		a.out.Flush()
+	}()
+
+	a.refresh()
+	for {
+		a.draw()
+		key, err := in.ReadKey()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if quit := a.handle(key); quit {
+			return nil
+		}
+	}
+}
+
+func (a *app) path() string {
+	return a.paths[a.cur]
+}
+
+// reloads the current list from disk and re-renders it
+func (a *app) refresh() {
+	task.AdjustTime()
+	if w, h, err := term.Size(a.fd); err == nil {
+		a.width, a.height = w, h
+	} else {
+		a.width, a.height = 80, 24
+	}
+	a.width = max(a.width, 50)
+	var selected *int
+	if a.cursor < len(a.lines) {
+		selected = a.lines[a.cursor].ID
+	}
+	if err := task.LoadFile(a.path()); err != nil {
+		a.lines = nil
+		a.status = err.Error()
+		return
+	}
+	lines, err := task.RenderLines(a.path(), a.width-1, a.width-1)
+	if err != nil {
+		a.lines = nil
+		a.status = err.Error()
+		return
+	}
+	a.lines = lines
+	a.cursor = 0
+	if selected != nil {
+		for ndx, line := range a.lines {
+			if line.ID != nil && *line.ID == *selected && a.visible(ndx) {
+				a.cursor = ndx
+				return
+			}
+		}
+	}
+	a.move(1)
+}
+
+func (a *app) visible(ndx int) bool {
+	if ndx == 0 { // list header
+		return true
+	}
+	line := a.lines[ndx]
+	if a.filter == "" {
+		return true
+	}
+	return line.ID != nil && strings.Contains(strings.ToLower(line.Raw), strings.ToLower(a.filter))
+}
+
+// moves the cursor by step selectable lines; if the cursor is
+// not upon a selectable line the nearest one in the direction of step is chosen
+func (a *app) move(step int) {
+	selectable := func(ndx int) bool {
+		return ndx >= 0 && ndx < len(a.lines) && a.lines[ndx].ID != nil && a.visible(ndx)
+	}
+	dir := 1
+	if step < 0 {
+		dir, step = -1, -step
+	}
+	if !selectable(a.cursor) {
+		for ndx := a.cursor; ndx >= 0 && ndx < len(a.lines); ndx += dir {
+			if selectable(ndx) {
+				a.cursor = ndx
+				return
+			}
+		}
+		for ndx := a.cursor; ndx >= 0 && ndx < len(a.lines); ndx -= dir {
+			if selectable(ndx) {
+				a.cursor = ndx
+				return
+			}
+		}
+		return
+	}
+	for ; step > 0; step-- {
+		next := a.cursor + dir
+		for next >= 0 && next < len(a.lines) && !selectable(next) {
+			next += dir
+		}
+		if !selectable(next) {
+			return
+		}
+		a.cursor = next
+	}
+}
+
+func (a *app) selectedID() (int, bool) {
+	if a.cursor >= len(a.lines) || a.lines[a.cursor].ID == nil {
+		return -1, false
+	}
+	return *a.lines[a.cursor].ID, true
+}
+
+// loads the current list, applies f, and stores the list back
+func (a *app) apply(f func(path string) error) {
+	path := a.path()
+	err := task.LoadFile(path)
+	if err == nil {
+		err = f(path)
+	}
+	if err == nil {
+		err = task.StoreFile(path)
+	}
+	if err != nil {
+		a.status = err.Error()
+	}
+	a.refresh()
+}
+
+func (a *app) applyToSelected(f func(id int, path string) error) {
+	id, ok := a.selectedID()
+	if !ok {
+		a.status = "no task selected"
+		return
+	}
+	a.apply(func(path string) error {
+		return f(id, path)
+	})
+}
+
+func (a *app) prompt(prompt, initial string) (string, bool) {
+	fmt.Fprintf(a.out, "\x1b[%d;1H\x1b[0m\x1b[?25h", a.height)
+	a.out.Flush()
+	defer a.out.WriteString("\x1b[?25l")
+	out, err := a.editor.ReadLine(prompt, initial)
+	if err != nil {
+		return "", false
+	}
+	return out, true
+}
+
+func (a *app) handle(key term.Key) bool {
+	a.status = ""
+	switch key.Code {
+	case term.KeyCtrl:
+		switch key.Rune {
+		case 'c':
+			return true
+		case 'n':
+			a.move(1)
+		case 'p':
+			a.move(-1)
+		case 'l':
+			a.refresh()
+		}
+	case term.KeyDown:
+		a.move(1)
+	case term.KeyUp:
+		a.move(-1)
+	case term.KeyPgDown:
+		a.move(a.height / 2)
+	case term.KeyPgUp:
+		a.move(-a.height / 2)
+	case term.KeyTab, term.KeyRight:
+		a.switchList(1)
+	case term.KeyBackTab, term.KeyLeft:
+		a.switchList(-1)
+	case term.KeyEnter:
+		a.applyToSelected(task.ToggleCollapsed)
+	case term.KeyEsc:
+		if a.filter != "" {
+			a.filter = ""
+			a.refresh()
+		}
+	case term.KeyRune:
+		return a.handleRune(key.Rune)
+	}
+	return false
+}
+
+func (a *app) handleRune(r rune) bool {
+	switch r {
+	case 'q':
+		return true
+	case 'j':
+		a.move(1)
+	case 'k':
+		a.move(-1)
+	case 'g':
+		a.cursor = 0
+		a.move(1)
+	case 'G':
+		a.cursor = len(a.lines) - 1
+		a.move(-1)
+	case 'l':
+		a.switchList(1)
+	case 'h':
+		a.switchList(-1)
+	case ' ', 'c':
+		a.applyToSelected(task.ToggleCollapsed)
+	case 'f':
+		a.applyToSelected(task.ToggleFocus)
+	case 'x':
+		a.applyToSelected(func(id int, path string) error {
+			return task.DoneTask([]int{id}, path)
+		})
+	case '+', '=':
+		a.applyToSelected(func(id int, path string) error {
+			return task.IncrementProgressCount(id, path, 1)
+		})
+	case '-':
+		a.applyToSelected(func(id int, path string) error {
+			return task.IncrementProgressCount(id, path, -1)
+		})
+	case 'p':
+		if _, ok := a.selectedID(); !ok {
+			return false
+		}
+		priority, ok := a.prompt("priority (empty removes): ", "")
+		if !ok {
+			return false
+		}
+		a.applyToSelected(func(id int, path string) error {
+			if strings.TrimSpace(priority) == "" {
+				return task.DeprioritizeTask(id, path)
+			}
+			return task.PrioritizeTask(id, priority, path)
+		})
+	case 'e':
+		if _, ok := a.selectedID(); !ok {
+			return false
+		}
+		text, ok := a.prompt("edit: ", a.lines[a.cursor].Raw)
+		if !ok || strings.TrimSpace(text) == "" {
+			return false
+		}
+		a.applyToSelected(func(id int, path string) error {
+			return task.ReplaceTask(id, text, path)
+		})
+	case 'a':
+		text, ok := a.prompt("add: ", "")
+		if !ok || strings.TrimSpace(text) == "" {
+			return false
+		}
+		a.apply(func(path string) error {
+			return task.AddTaskFromStr(text, path)
+		})
+	case '/':
+		filter, ok := a.prompt("filter: ", a.filter)
+		if !ok {
+			return false
+		}
+		a.filter = strings.TrimSpace(filter)
+		a.refresh()
+	case 'r':
+		a.refresh()
+	default:
+		if '1' <= r && r <= '9' && int(r-'1') < len(a.paths) {
+			a.cur = int(r - '1')
+			a.cursor, a.offset = 0, 0
+			a.refresh()
+		}
+	}
+	return false
+}
+
+func (a *app) switchList(step int) {
+	n := len(a.paths)
+	a.cur = ((a.cur+step)%n + n) % n
+	a.cursor, a.offset = 0, 0
+	a.refresh()
+}
+
+func (a *app) draw() {
+	contentHeight := max(a.height-2, 1)
+	type row struct {
+		text     string
+		selected bool
+	}
+	var rows []row
+	cursorStart, cursorEnd := -1, -1
+	for ndx, line := range a.lines {
+		if !a.visible(ndx) {
+			continue
+		}
+		if ndx == a.cursor {
+			cursorStart = len(rows)
+		}
+		for part := range strings.SplitSeq(line.Text, "\n") {
+			rows = append(rows, row{text: part, selected: ndx == a.cursor && line.ID != nil})
+		}
+		if ndx == a.cursor {
+			cursorEnd = len(rows)
+		}
+	}
+	if cursorStart != -1 {
+		if cursorStart < a.offset {
+			a.offset = cursorStart
+		} else if cursorEnd > a.offset+contentHeight {
+			a.offset = cursorEnd - contentHeight
+		}
+	}
+	a.offset = max(min(a.offset, len(rows)-contentHeight), 0)
+
+	a.out.WriteString("\x1b[H")
+	var tabs strings.Builder
+	for ndx, name := range a.names {
+		if ndx == a.cur {
+			tabs.WriteString("\x1b[7m " + name + " \x1b[0m")
+		} else {
+			tabs.WriteString(" " + name + " ")
+		}
+	}
+	if a.filter != "" {
+		tabs.WriteString(" /" + a.filter)
+	}
+	a.out.WriteString(tabs.String() + "\x1b[K\r\n")
+	for ndx := a.offset; ndx < a.offset+contentHeight; ndx++ {
+		if ndx < len(rows) {
+			if rows[ndx].selected {
+				a.out.WriteString("\x1b[7m")
+			}
+			a.out.WriteString(utils.ColorMarkupToANSI(rows[ndx].text))
+			a.out.WriteString("\x1b[0m")
+		}
+		a.out.WriteString("\x1b[K\r\n")
+	}
+	status := a.status
+	if status == "" {
+		status = helpLine
+	}
+	if utils.RuneCount(status) > a.width {
+		status = utils.RuneSlice(status, 0, a.width)
+	}
+	a.out.WriteString("\x1b[2m" + status + "\x1b[0m\x1b[K")
+	a.out.Flush()
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"dotxt/pkg/task"
+	"dotxt/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMove(t *testing.T) {
+	assert := assert.New(t)
+	a := &app{lines: []task.Line{
+		{Text: "header"},
+		{ID: utils.MkPtr(0), Raw: "first +a"},
+		{Text: "... -1 ..."},
+		{ID: utils.MkPtr(1), Raw: "second +b"},
+		{ID: utils.MkPtr(2), Raw: "third +a"},
+	}}
+	a.move(1)
+	assert.Equal(1, a.cursor)
+	a.move(1)
+	assert.Equal(3, a.cursor)
+	a.move(5)
+	assert.Equal(4, a.cursor)
+	a.move(-1)
+	assert.Equal(3, a.cursor)
+	a.move(-5)
+	assert.Equal(1, a.cursor)
+
+	t.Run("filter", func(t *testing.T) {
+		a.filter = "+A"
+		assert.True(a.visible(0))
+		assert.True(a.visible(1))
+		assert.False(a.visible(2))
+		assert.False(a.visible(3))
+		a.move(1)
+		assert.Equal(4, a.cursor)
+		a.cursor = 3
+		a.move(1)
+		assert.Equal(4, a.cursor)
+	})
+}
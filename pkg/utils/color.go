@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 )
 
 // AI generated
@@ -170,3 +171,28 @@ func HexToHSL(hex string) (float64, float64, float64) {
 
 	return h, s * 100, l * 100
 }
+
+// converts the "${color #RRGGBB}" markup that is produced in colored mode
+// into ANSI true color escape sequences
+func ColorMarkupToANSI(text string) string {
+	var out strings.Builder
+	for {
+		ndx := strings.Index(text, "${color ")
+		if ndx == -1 || len(text) < ndx+16 || text[ndx+15] != '}' {
+			out.WriteString(text)
+			break
+		}
+		out.WriteString(text[:ndx])
+		hex := text[ndx+8 : ndx+15]
+		r, rerr := strconv.ParseUint(hex[1:3], 16, 8)
+		g, gerr := strconv.ParseUint(hex[3:5], 16, 8)
+		b, berr := strconv.ParseUint(hex[5:7], 16, 8)
+		if hex[0] != '#' || rerr != nil || gerr != nil || berr != nil {
+			out.WriteString(text[ndx : ndx+16])
+		} else {
+			fmt.Fprintf(&out, "\x1b[38;2;%d;%d;%dm", r, g, b)
+		}
+		text = text[ndx+16:]
+	}
+	return out.String()
+}
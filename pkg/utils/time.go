@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// a zone by its iana name (e.g. Europe/Berlin), UTC or Z,
+// or a fixed offset such as +03, +0330 or -05:00
+func ParseZone(name string) (*time.Location, error) {
+	switch name {
+	case "":
+		return nil, fmt.Errorf("empty zone")
+	case "UTC", "Z", "z":
+		return time.UTC, nil
+	}
+	if name[0] == '+' || name[0] == '-' {
+		digits := name[1:]
+		if len(digits) == 5 && digits[2] == ':' {
+			digits = digits[:2] + digits[3:]
+		}
+		if len(digits) != 2 && len(digits) != 4 {
+			return nil, fmt.Errorf("invalid offset '%s'", name)
+		}
+		hours, err := strconv.Atoi(digits[:2])
+		if err != nil || hours > 14 {
+			return nil, fmt.Errorf("invalid offset '%s'", name)
+		}
+		var minutes int
+		if len(digits) == 4 {
+			minutes, err = strconv.Atoi(digits[2:])
+			if err != nil || minutes >= 60 {
+				return nil, fmt.Errorf("invalid offset '%s'", name)
+			}
+		}
+		offset := hours*60*60 + minutes*60
+		if name[0] == '-' {
+			offset = -offset
+		}
+		return time.FixedZone(fmt.Sprintf("%c%02d%02d", name[0], hours, minutes), offset), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown zone '%s': %w", name, err)
+	}
+	return loc, nil
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/unicode/norm"
@@ -101,3 +102,34 @@ func TestRuneSlice(t *testing.T) {
 		assert.Equal("ab", RuneSlice("ab", 0))
 	})
 }
+
+func TestColorMarkupToANSI(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("plain", ColorMarkupToANSI("plain"))
+	assert.Equal("\x1b[38;2;255;0;16mred", ColorMarkupToANSI("${color #FF0010}red"))
+	assert.Equal("a\x1b[38;2;0;0;0mb\x1b[38;2;255;255;255mc",
+		ColorMarkupToANSI("a${color #000000}b${color #ffffff}c"))
+	assert.Equal("${color #GG0000}x", ColorMarkupToANSI("${color #GG0000}x"))
+	assert.Equal("${color #FF}", ColorMarkupToANSI("${color #FF}"))
+}
+
+func TestParseZone(t *testing.T) {
+	assert := assert.New(t)
+	loc, err := ParseZone("UTC")
+	assert.NoError(err)
+	assert.Equal(time.UTC, loc)
+	for name, offset := range map[string]int{"+03": 3 * 3600, "-0530": -(5*3600 + 30*60), "+04:30": 4*3600 + 30*60} {
+		loc, err := ParseZone(name)
+		if assert.NoError(err, name) {
+			_, off := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone()
+			assert.Equal(offset, off, name)
+		}
+	}
+	loc, err = ParseZone("Europe/Berlin")
+	assert.NoError(err)
+	assert.Equal("Europe/Berlin", loc.String())
+	for _, name := range []string{"", "+3", "+15", "+0360", "Nowhere/City"} {
+		_, err := ParseZone(name)
+		assert.Error(err, name)
+	}
+}